@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// getActiveEntry returns the running or paused entry a command should act on.
+//
+// If args contains an entry ID (or unique prefix), that entry is returned, provided it is running or paused. Otherwise,
+// the entry on the default timer is returned (see [defaultTimerEntry]). When the default timer has several active
+// entries, they are listed with a warning and nil is returned, so the caller can stop without guessing which one was
+// meant. Entries on named timers never make the choice ambiguous.
+//
+// Returns nil without an error if no entry is active or the choice is ambiguous. Returns an error if the ID cannot be
+// resolved, the entry is not active, or a database query fails.
+func getActiveEntry(args []string) (*model.Entry, error) {
+	if len(args) > 0 {
+		entryID, err := db.ResolveEntryID(args[0])
+		if err != nil {
+			return nil, err
+		}
+		entry, err := db.GetEntryByID(entryID)
+		if err != nil {
+			return nil, fmt.Errorf("entry not found: %w", err)
+		}
+		if entry.Status == model.StatusStopped {
+			return nil, fmt.Errorf("entry %s is not running", entry.ID)
+		}
+		return entry, nil
+	}
+
+	counts, err := db.CountActiveEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active entries: %w", err)
+	}
+	if counts[""] > 1 {
+		return nil, warnMultipleActive("")
+	}
+
+	entry, err := defaultTimerEntry(counts)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		if len(counts) > 0 {
+			fmt.Printf("No timer running on the default timer. Use --timer to choose one of: %s\n",
+				strings.Join(slices.Sorted(maps.Keys(counts)), ", "))
+		} else {
+			fmt.Println("No timer running")
+		}
+	}
+	return entry, nil
+}
+
+// defaultTimerEntry returns the entry a command without an ID or --timer acts on, given the active entries per timer in
+// counts, as returned by [db.CountActiveEntries].
+//
+// This is the entry on the default timer. When the default timer is idle but a single entry is active on a named
+// timer, that entry is returned instead, so a lone named timer can be stopped without naming it.
+//
+// Returns nil without an error if there is no such entry, or an error if a database query fails.
+func defaultTimerEntry(counts map[string]int) (*model.Entry, error) {
+	var entry *model.Entry
+	var err error
+	switch {
+	case counts[""] > 0:
+		entry, err = db.GetRunningEntryForTimer("")
+	case len(counts) == 1 && slices.Collect(maps.Values(counts))[0] == 1:
+		entry, err = db.GetRunningEntry()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running entry: %w", err)
+	}
+	return entry, nil
+}
+
+// warnMultipleActive prints a warning listing the running or paused entries on timer and how to pick one by ID.
+func warnMultipleActive(timer string) error {
+	active, err := db.ListActiveEntries()
+	if err != nil {
+		return fmt.Errorf("failed to list active entries: %w", err)
+	}
+	var entries []model.Entry
+	for _, e := range active {
+		if e.Timer == timer {
+			entries = append(entries, e)
+		}
+	}
+
+	fmt.Printf("Warning: %d entries are active on %s. Specify an entry ID to choose one:\n\n", len(entries), timerLabel(timer))
+	printEntriesTable(os.Stdout, entries)
+	return nil
+}
+
+// timerLabel names timer in messages, e.g. "timer 'deep'" or "the default timer".
+func timerLabel(timer string) string {
+	if timer == "" {
+		return "the default timer"
+	}
+	return fmt.Sprintf("timer '%s'", timer)
+}
+
+// getActiveEntryForTimer returns the running or paused entry a command should act on, like [getActiveEntry], but
+// looks up the named timer instead when timer is not empty. An ID and a timer cannot be given together.
+//
+// Returns nil without an error if the timer is not active. Returns an error if both an ID and a timer are given or a
+// database query fails.
+func getActiveEntryForTimer(args []string, timer string) (*model.Entry, error) {
+	if timer == "" {
+		return getActiveEntry(args)
+	}
+	if len(args) > 0 {
+		return nil, fmt.Errorf("specify either an entry ID or --timer, not both")
+	}
+
+	counts, err := db.CountActiveEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active entries: %w", err)
+	}
+	if counts[timer] > 1 {
+		return nil, warnMultipleActive(timer)
+	}
+
+	entry, err := db.GetRunningEntryForTimer(timer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running entry: %w", err)
+	}
+	if entry == nil {
+		fmt.Printf("No timer running on '%s'\n", timer)
+	}
+	return entry, nil
+}
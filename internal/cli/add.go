@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// addFrom and addTo specify the start and end time of the entry created by [addCmd].
+//
+// addAgo specifies the start as a duration before now, such as "2h", instead of addFrom.
+//
+// addDuration specifies the length of the entry, from which the end is computed when the start is given, or the start
+// when only the end (or nothing) is given.
+//
+// addBillable specifies whether the entry is billable.
+//
+// addMessage sets the title explicitly, like [startMessage].
+var (
+	addFrom     string
+	addTo       string
+	addAgo      string
+	addDuration string
+	addBillable bool
+	addMessage  string
+)
+
+// addCmd records a completed entry after the fact, without starting a timer.
+//
+// Arguments follow the same @project "title" +tag syntax as [startCmd]. The entry is refused if it overlaps an
+// existing entry.
+var addCmd = &cobra.Command{
+	Use:   "add @project [\"title\"] [+tag]... --from <time> [--to <time> | --duration <d>]",
+	Short: "Add a completed entry for time you forgot to track",
+	Long: `Add a completed entry without running a timer.
+
+Times accept HH:MM, HH:MM:SS, or YYYY-MM-DD HH:MM:SS. --to defaults to now.
+
+Instead of exact times, give --ago for a start relative to now and
+--duration for the length of the entry. With a start (--from or --ago),
+--duration sets the end; without one, the entry ends at --to or now and
+starts --duration earlier. Durations look like 90m, 1h30m, or 2d.
+
+Examples:
+  tally add @work "standup" --from 09:00 --to 09:15
+  tally add @work "deploy" +ops --from "2024-01-01 09:00" --to "2024-01-01 11:30"
+  tally add @work "training" --from 14:00 --to 15:00 --billable=false
+  tally add @work -m "@team sync" --from 10:00 --to 10:30
+  tally add @work "review" --ago 2h --duration 90m   # Started 2 hours ago, lasted 90 minutes
+  tally add @work "call" --from 14:00 --duration 45m
+  tally add @work "debugging" --duration 1h          # The last hour`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAdd,
+}
+
+func init() {
+	addCmd.Flags().StringVarP(&addFrom, "from", "f", "", "Start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	addCmd.Flags().StringVarP(&addTo, "to", "t", "", "End time (HH:MM or YYYY-MM-DD HH:MM:SS), defaults to now")
+	addCmd.Flags().StringVar(&addAgo, "ago", "", "Start this long before now, e.g. 2h or 1h30m, instead of --from")
+	addCmd.Flags().StringVar(&addDuration, "duration", "", "Length of the entry, e.g. 90m, instead of --to")
+	addCmd.Flags().BoolVar(&addBillable, "billable", true, "Mark the entry as billable (--billable=false for non-billable work)")
+	addCmd.Flags().StringVarP(&addMessage, "message", "m", "", "Title of the entry, instead of a positional title")
+}
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	projectName, title, tagNames, err := parseStartArgs(args, addMessage)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := parseAddRange(db.Now())
+	if err != nil {
+		return err
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end time must be after start time")
+	}
+	cmd.SilenceUsage = true
+
+	overlapping, err := db.EntriesOverlapping(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to check for overlapping entries: %w", err)
+	}
+	if len(overlapping) > 0 {
+		fmt.Printf("The entry %s - %s overlaps existing entries:\n\n", start.Format("15:04"), end.Format("15:04"))
+		printEntriesTable(os.Stdout, overlapping)
+		return fmt.Errorf("refusing to create overlapping entry")
+	}
+
+	entry, err := createCompletedEntry(projectName, title, tagNames, start, end, "")
+	if err != nil {
+		return err
+	}
+	if !addBillable {
+		if err := db.SetEntryBillable(entry.ID, false); err != nil {
+			return fmt.Errorf("failed to mark entry non-billable: %w", err)
+		}
+		entry.Billable = false
+	}
+
+	fmt.Printf("Added @%s", projectName)
+	if entry.Title != "" {
+		fmt.Printf(": %s", entry.Title)
+	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf(" %s", formatTagsFromModel(entry.Tags))
+	}
+	fmt.Printf(" [%s - %s, %s]", start.Format("2006-01-02 15:04"), end.Format("15:04"), formatDuration(entry.Duration()))
+	if !entry.Billable {
+		fmt.Print(" (non-billable)")
+	}
+	fmt.Println()
+	return nil
+}
+
+// parseAddRange computes the start and end of the entry added by [addCmd] from --from or --ago, --to, and --duration,
+// as of now.
+//
+// The start comes from --from or --ago. With --duration, the end is the start plus the duration, or, without a
+// start, the start is the end (--to or now) minus the duration. Otherwise the end is --to or now.
+//
+// Returns an error if --from and --ago, or a start, --to, and --duration are combined, if neither a start nor
+// --duration is given, or if a value cannot be parsed.
+func parseAddRange(now time.Time) (start, end time.Time, err error) {
+	if addFrom != "" && addAgo != "" {
+		return start, end, fmt.Errorf("--ago cannot be combined with --from")
+	}
+	hasStart := addFrom != "" || addAgo != ""
+	if hasStart && addTo != "" && addDuration != "" {
+		return start, end, fmt.Errorf("--duration cannot be combined with both a start and --to")
+	}
+	if !hasStart && addDuration == "" {
+		return start, end, fmt.Errorf("--from, --ago, or --duration is required")
+	}
+
+	var duration time.Duration
+	if addDuration != "" {
+		if duration, err = parseDurationInput(addDuration); err != nil {
+			return start, end, fmt.Errorf("invalid --duration: %w", err)
+		}
+	}
+
+	end = now
+	if addTo != "" {
+		if end, err = parseTimeInput(addTo); err != nil {
+			return start, end, err
+		}
+	}
+
+	switch {
+	case addFrom != "":
+		if start, err = parseTimeInput(addFrom); err != nil {
+			return start, end, err
+		}
+	case addAgo != "":
+		ago, err := parseDurationInput(addAgo)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid --ago: %w", err)
+		}
+		start = now.Add(-ago)
+	default:
+		return end.Add(-duration), end, nil
+	}
+
+	if addDuration != "" {
+		end = start.Add(duration)
+	}
+	return start, end, nil
+}
+
+// parseDurationInput parses a positive duration such as "90m" or "1h30m", or a whole number of days or weeks such as
+// "2d" (see [parseRelativeDuration]).
+//
+// Returns an error if the value is not a positive duration.
+func parseDurationInput(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		if d, err = parseRelativeDuration(value); err != nil {
+			return 0, fmt.Errorf("%q is not a duration like 90m, 1h30m, or 2d", value)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%q is not a positive duration", value)
+	}
+	return d, nil
+}
+
+// createCompletedEntry creates a stopped entry from start to end, creating the project and tags by name if needed,
+// the same way [runStart] does. sourceID is the entry's ID in the tool it was imported from, or empty.
+//
+// Returns the created entry, or an error if a project, tag, or the entry cannot be created.
+func createCompletedEntry(projectName, title string, tagNames []string, start, end time.Time, sourceID string) (*model.Entry, error) {
+	project, err := db.GetOrCreateProject(projectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get/create project: %w", err)
+	}
+
+	var tagIDs []string
+	for _, name := range tagNames {
+		tag, err := db.GetOrCreateTag(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get/create tag '%s': %w", name, err)
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	entry, err := db.CreateImportedEntry(project.ID, title, tagIDs, start, end, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entry: %w", err)
+	}
+	return entry, nil
+}
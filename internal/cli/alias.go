@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// aliasFormat defines the output format of [aliasListCmd]. Accepts "table" (default) or "json".
+var aliasFormat string
+
+// aliasCmd makes a short name resolve to a project, so `@wk` can be typed for `@work` without renaming the project.
+//
+// Aliases are resolved wherever a project is looked up by name, see [db.ResolveProjectAlias].
+var aliasCmd = &cobra.Command{
+	Use:   "alias @alias @project",
+	Short: "Add a short name for a project",
+	Long: `Make @alias stand for @project wherever a project is named, such as
+'tally start @wk' for @work. Setting an existing alias points it at the new
+project. An alias cannot have the name of an existing project.
+
+Examples:
+  tally alias @wk @work     # @wk now means @work
+  tally alias list          # Show all aliases
+  tally alias delete @wk    # Remove the alias, keeping @work`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAlias,
+}
+
+// aliasListCmd lists the project aliases.
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List project aliases",
+	Args:  cobra.NoArgs,
+	RunE:  runAliasList,
+}
+
+// aliasDeleteCmd removes a project alias. The project it stood for is kept.
+var aliasDeleteCmd = &cobra.Command{
+	Use:   "delete @alias",
+	Short: "Delete a project alias",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAliasDelete,
+}
+
+func init() {
+	aliasListCmd.Flags().StringVar(&aliasFormat, "format", "table", "Output format: table, json")
+
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasDeleteCmd)
+}
+
+// runAlias points the alias in args[0] at the existing project in args[1], with or without their "@" prefixes.
+//
+// Returns an error if the project does not exist, the alias names an existing project, or the alias cannot be saved.
+func runAlias(cmd *cobra.Command, args []string) error {
+	alias := strings.TrimPrefix(args[0], "@")
+	projectName := strings.TrimPrefix(args[1], "@")
+	if alias == "" || strings.ContainsAny(alias, " \t+@") {
+		return fmt.Errorf("invalid alias: %s", args[0])
+	}
+	cmd.SilenceUsage = true
+
+	project, err := db.GetProjectByName(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return fmt.Errorf("project @%s not found", projectName)
+	}
+
+	if err := db.SetProjectAlias(alias, project.ID); err != nil {
+		return fmt.Errorf("failed to set alias: %w", err)
+	}
+	fmt.Printf("@%s -> @%s\n", alias, project.Name)
+	return nil
+}
+
+// runAliasList lists aliases using [db.ListProjectAliases] in the requested format.
+func runAliasList(cmd *cobra.Command, args []string) error {
+	if aliasFormat != "table" && aliasFormat != "json" {
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", aliasFormat)
+	}
+	cmd.SilenceUsage = true
+
+	aliases, err := db.ListProjectAliases()
+	if err != nil {
+		return fmt.Errorf("failed to list aliases: %w", err)
+	}
+
+	if aliasFormat == "json" {
+		if aliases == nil {
+			aliases = []model.ProjectAlias{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(aliases)
+	}
+
+	if len(aliases) == 0 {
+		fmt.Println("No aliases found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Alias", "Project"})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	for _, a := range aliases {
+		table.Append([]string{"@" + a.Alias, "@" + a.Project})
+	}
+	table.Render()
+	return nil
+}
+
+// runAliasDelete deletes the alias in args[0] with [db.DeleteProjectAlias].
+func runAliasDelete(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	alias := strings.TrimPrefix(args[0], "@")
+	deleted, err := db.DeleteProjectAlias(alias)
+	if err != nil {
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+	if !deleted {
+		fmt.Printf("No alias named @%s\n", alias)
+		return nil
+	}
+	fmt.Printf("Deleted alias @%s\n", alias)
+	return nil
+}
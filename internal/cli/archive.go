@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// archiveCmd hides a project that is no longer worked on.
+//
+// Archived projects are left out of `tally projects`, `tally log`, and completion, but their entries still count in
+// reports. Starting a timer on an archived project offers to unarchive it.
+var archiveCmd = &cobra.Command{
+	Use:   "archive <@project>",
+	Short: "Archive a project",
+	Long: `Hide a project from 'tally projects', 'tally log', and completion.
+
+Entries of archived projects still count in reports. Use --all with
+'projects' or 'log' to include them.
+
+Example:
+  tally archive @old-client`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setArchived(cmd, args[0], true)
+	},
+}
+
+// unarchiveCmd restores an archived project to listings and completion.
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <@project>",
+	Short: "Unarchive a project",
+	Long: `Show an archived project in listings and completion again.
+
+Example:
+  tally unarchive @old-client`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setArchived(cmd, args[0], false)
+	},
+}
+
+// setArchived archives or unarchives the project named by arg, which must use the "@" prefix.
+//
+// Returns an error if arg is not a project, the project does not exist, or the update fails.
+func setArchived(cmd *cobra.Command, arg string, archived bool) error {
+	if !strings.HasPrefix(arg, "@") {
+		return fmt.Errorf("project must be given as @name")
+	}
+	name := strings.TrimPrefix(arg, "@")
+	cmd.SilenceUsage = true
+
+	project, err := db.GetProjectByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if project == nil {
+		return fmt.Errorf("project @%s not found", name)
+	}
+
+	verb := "Archived"
+	if !archived {
+		verb = "Unarchived"
+	}
+	if project.Archived == archived {
+		fmt.Printf("@%s is already %s\n", name, strings.ToLower(verb))
+		return nil
+	}
+
+	if err := db.SetProjectArchived(project.ID, archived); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	fmt.Printf("%s @%s\n", verb, name)
+	return nil
+}
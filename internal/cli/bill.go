@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// billFrom and billTo restrict [billCmd] to entries starting within the given dates, inclusive.
+//
+// billDryRun specifies whether the entries that would be marked are only listed, without modifying them.
+var (
+	billFrom   string
+	billTo     string
+	billDryRun bool
+)
+
+// billCmd marks matching entries as billed after they have been invoiced, so later reports leave them out.
+//
+// Only stopped, billable entries that have not been billed yet are marked. Entries are selected with the same filters
+// as [tagCmd], and at least one filter is required so a mistyped command cannot mark every entry.
+var billCmd = &cobra.Command{
+	Use:   "bill [@project] [+tag]... [--from <date>] [--to <date>]",
+	Short: "Mark invoiced entries as billed",
+	Long: `Mark stopped, billable entries as billed once they have been invoiced.
+
+Reports leave billed entries out unless --include-billed is given, so the
+next report only shows time that still has to be invoiced. Entries are
+selected with @project, +tag, --from, and --to, as in 'tally log'.
+
+Examples:
+  tally bill @work --from 2024-01-01 --to 2024-01-31
+  tally bill @work --to 2024-01-31 --dry-run   # List what would be marked`,
+	RunE: runBill,
+}
+
+func init() {
+	billCmd.Flags().StringVar(&billFrom, "from", "", "Start date (YYYY-MM-DD)")
+	billCmd.Flags().StringVar(&billTo, "to", "", "End date (YYYY-MM-DD)")
+	billCmd.Flags().BoolVar(&billDryRun, "dry-run", false, "List the entries that would be marked without marking them")
+}
+
+func runBill(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && billFrom == "" && billTo == "" {
+		return fmt.Errorf("at least one filter (@project, +tag, --from, or --to) is required")
+	}
+	cmd.SilenceUsage = true
+
+	opts, err := parseEntryFilter(args, billFrom, billTo)
+	if err != nil {
+		return err
+	}
+	stopped := model.StatusStopped
+	billable, billed := true, false
+	opts.Status = &stopped
+	opts.Billable = &billable
+	opts.Billed = &billed
+
+	entries, err := db.ListEntries(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No unbilled entries match")
+		return nil
+	}
+
+	ids := make([]string, len(entries))
+	var total time.Duration
+	for i, e := range entries {
+		ids[i] = e.ID
+		total += e.Duration()
+	}
+
+	if billDryRun {
+		printEntriesTable(os.Stdout, entries)
+		fmt.Printf("\nDry run: would have marked %d entries (%s) as billed\n", len(entries), formatDuration(total))
+		return nil
+	}
+
+	if err := db.MarkBilled(ids, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark entries as billed: %w", err)
+	}
+	fmt.Printf("Marked %d entries (%s) as billed\n", len(entries), formatDuration(total))
+	return nil
+}
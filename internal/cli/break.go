@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// defaultBreakReason is the pause reason recorded by `tally break` when none is given.
+const defaultBreakReason = "break"
+
+// breakCmd pauses the active timer with a reason, defaulting to "break".
+//
+// It is a shorthand for pausing now; the reason is stored on the pause so breaks can be told apart from other
+// interruptions later.
+var breakCmd = &cobra.Command{
+	Use:   "break [reason]",
+	Short: "Take a break (pause the current timer with a reason)",
+	Long: `Pause the current timer and record why. The reason defaults to "break".
+
+Use 'tally back' to resume when you return.
+
+Examples:
+  tally break           # Pause with reason "break"
+  tally break lunch     # Pause with reason "lunch"
+  tally break coffee run`,
+	RunE: runBreak,
+}
+
+// backCmd resumes the paused timer after a break.
+var backCmd = &cobra.Command{
+	Use:   "back",
+	Short: "Return from a break (resume the paused timer)",
+	Long:  `Resume the paused timer. This behaves exactly like 'tally resume' with no arguments.`,
+	Args:  cobra.NoArgs,
+	RunE:  runBack,
+}
+
+func runBreak(cmd *cobra.Command, args []string) error {
+	reason := strings.TrimSpace(strings.Join(args, " "))
+	if reason == "" {
+		reason = defaultBreakReason
+	}
+
+	entry, err := getActiveEntry(nil)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	return pauseNow(entry, reason)
+}
+
+func runBack(cmd *cobra.Command, args []string) error {
+	return resumeDefault(db.Now())
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/color"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// update rewrites the golden files compared by [assertGolden] instead of checking them: go test ./internal/cli -update
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// openTestDB initializes [db.DB] in a temporary data directory, with times in UTC (see [db.Location]), until the test
+// ends.
+func openTestDB(t *testing.T) {
+	t.Helper()
+	db.DataDirOverride = t.TempDir()
+	if err := db.Init(); err != nil {
+		t.Fatalf("db.Init() error = %v", err)
+	}
+	original := db.Location
+	db.Location = time.UTC
+	t.Cleanup(func() {
+		db.Close()
+		db.DB = nil
+		db.DataDirOverride = ""
+		db.Location = original
+	})
+}
+
+// assertGolden compares got with testdata/name.golden, or writes it there when -update is given.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s (run go test with -update to accept it)\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// plainOutput turns colors off and times to UTC until the test ends, so output is the same on every machine.
+func plainOutput(t *testing.T) {
+	t.Helper()
+	if err := color.Setup(color.Never); err != nil {
+		t.Fatal(err)
+	}
+	original := db.Location
+	db.Location = time.UTC
+	t.Cleanup(func() { db.Location = original })
+}
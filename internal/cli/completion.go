@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// recentEntriesForCompletion is the number of recent entries offered when completing an entry ID.
+const recentEntriesForCompletion = 20
+
+// completionCmd prints a shell completion script.
+//
+// The scripts complete commands and flags, as well as existing @project and +tag names and recent entry IDs, which
+// are looked up in the database when Tab is pressed.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a shell completion script for tally.
+
+Bash:
+  tally completion bash > ~/.local/share/bash-completion/completions/tally
+
+Zsh:
+  tally completion zsh > "${fpath[1]}/_tally"
+
+Fish:
+  tally completion fish > ~/.config/fish/completions/tally.fish
+
+Start a new shell for the completion to take effect, or run 'tally init'
+to install it for the current shell.`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return fmt.Errorf("unsupported shell: %s (use 'bash', 'zsh', or 'fish')", args[0])
+		}
+	},
+}
+
+// completeProjectsAndTags completes @project and +tag arguments from the names in the database.
+//
+// A word starting with "@" completes projects and project aliases, and a word starting with "+" completes tags; an
+// empty word offers both. Database errors yield no suggestions rather than an error, since completion must never get in the way.
+func completeProjectsAndTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if db.DB == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var suggestions []string
+	if toComplete == "" || strings.HasPrefix(toComplete, "@") {
+		names, _ := db.ListProjectNames()
+		for _, name := range names {
+			suggestions = append(suggestions, "@"+name)
+		}
+		aliases, _ := db.ListProjectAliases()
+		for _, a := range aliases {
+			suggestions = append(suggestions, "@"+a.Alias+"\talias of @"+a.Project)
+		}
+	}
+	if toComplete == "" || strings.HasPrefix(toComplete, "+") {
+		names, _ := db.ListTagNames()
+		for _, name := range names {
+			suggestions = append(suggestions, "+"+name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplateName completes a template name argument or flag with the saved templates, described by what they
+// start.
+func completeTemplateName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || db.DB == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	templates, err := db.ListTemplates()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions := make([]string, 0, len(templates))
+	for i := range templates {
+		suggestions = append(suggestions, templates[i].Name+"\t"+formatTemplate(&templates[i]))
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectAlias completes the alias argument of `tally alias delete` with the saved aliases, described by the
+// project they stand for.
+func completeProjectAlias(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || db.DB == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	aliases, err := db.ListProjectAliases()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		suggestions = append(suggestions, "@"+a.Alias+"\t@"+a.Project)
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEntryID completes the entry ID argument of commands such as edit and delete with the most recent entries,
+// described by project and title.
+func completeEntryID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || db.DB == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	entries, err := db.ListEntries(db.ListEntriesOptions{Limit: recentEntriesForCompletion})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		description := "@" + e.Project.Name
+		if e.Title != "" {
+			description += ": " + e.Title
+		}
+		suggestions = append(suggestions, e.ID+"\t"+description)
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeEntryIDPair completes the two entry IDs of [mergeCmd] like [completeEntryID].
+func completeEntryIDPair(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeEntryID(cmd, nil, toComplete)
+}
+
+func init() {
+	logCmd.ValidArgsFunction = completeProjectsAndTags
+	reportCmd.ValidArgsFunction = completeProjectsAndTags
+	startCmd.ValidArgsFunction = completeProjectsAndTags
+	addCmd.ValidArgsFunction = completeProjectsAndTags
+	restartCmd.ValidArgsFunction = completeProjectsAndTags
+	tagAddCmd.ValidArgsFunction = completeProjectsAndTags
+	tagRemoveCmd.ValidArgsFunction = completeProjectsAndTags
+	billCmd.ValidArgsFunction = completeProjectsAndTags
+	editCmd.ValidArgsFunction = completeEntryID
+	deleteCmd.ValidArgsFunction = completeEntryID
+	showCmd.ValidArgsFunction = completeEntryID
+	pausesCmd.ValidArgsFunction = completeEntryID
+	splitCmd.ValidArgsFunction = completeEntryID
+	mergeCmd.ValidArgsFunction = completeEntryIDPair
+	duplicateCmd.ValidArgsFunction = completeEntryID
+	noteCmd.ValidArgsFunction = completeEntryID
+	templateStartCmd.ValidArgsFunction = completeTemplateName
+	templateDeleteCmd.ValidArgsFunction = completeTemplateName
+	aliasDeleteCmd.ValidArgsFunction = completeProjectAlias
+}
@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/thinktide/tally/internal/config"
+	"github.com/thinktide/tally/internal/service"
 )
 
+// outputFormats lists the accepted values of the output.format setting.
+var outputFormats = []string{"table", "json", "csv", "markdown", "html"}
+
 // configCmd is a command for managing application configuration settings.
 //
 // It provides subcommands to list all settings, retrieve specific values, and modify configuration options.
@@ -21,12 +30,35 @@ var configCmd = &cobra.Command{
 
 Examples:
   tally config list                        # List all settings
+  tally config list --format json          # List all settings as JSON
   tally config get output.format           # Get a specific setting
+  tally config get output.format --json    # Get a specific setting as JSON
   tally config set output.format json      # Set a value
+  TALLY_OUTPUT_FORMAT=json tally report    # Override a setting for one run
+
+Every setting except data.location and rate.@<project> can be overridden by
+an environment variable named after its key: TALLY_ followed by the key in
+upper case, with '.' replaced by '_'. Environment variables take precedence
+over stored values, which take precedence over defaults.
 
 Available settings:
-  output.format           - Default output format (table/json/csv)
-  data.location           - Data directory path`,
+  output.format                      - Default output format (table/json/csv/markdown/html)
+  data.location                      - Data directory path, read from ~/.tally (TALLY_DATA_DIR and --data-dir override it)
+  start.auto_stop_previous           - Stop the running timer when starting a new one (true/false)
+  delete.default_yes                 - Default delete prompts to yes (true/false)
+  delete.require_typed_confirmation  - Require typing the project name to delete (true/false)
+  report.tag_separator               - Separator between tag prefix and value for --group-by tag-prefix
+  report.rounding                    - Round report durations up to this increment (0, 5m, 15m, 30m, ...)
+  report.currency                    - Currency symbol for billable amounts in reports
+  report.week_start                  - First day of the week for week and lastWeek (monday/sunday)
+  report.default_period              - Period reported when none is given, e.g. week (menu to ask)
+  timezone                           - IANA time zone for entering and showing times, e.g. America/New_York
+  goal.daily                         - Daily goal shown by status, e.g. 6h (empty for none)
+  goal.weekly                        - Weekly goal shown by status, e.g. 30h (empty for none)
+  idle.threshold                     - Pause the timer after this long without 'tally ping', e.g. 15m (empty for off)
+  sleep.detection                    - Record system sleep as pauses before status, stop, pause, report (true/false)
+  max_session                        - Warn when a timer has run longer than this, e.g. 12h (empty for off)
+  rate.@<project>                    - Hourly rate for a project or its alias, e.g. rate.@work 120`,
 }
 
 // configListCmd is a Cobra command that lists all configuration settings currently available in the application.
@@ -68,11 +100,22 @@ var configSetCmd = &cobra.Command{
 	RunE:  runConfigSet,
 }
 
+// configListFormat defines the output format of [configListCmd]. Accepts "table" (default) or "json".
+//
+// configGetJSON specifies whether [configGetCmd] prints the value as a JSON object instead of plain text.
+var (
+	configListFormat string
+	configGetJSON    bool
+)
+
 // init initializes and registers subcommands for the `config` command.
 //
 // It adds [configListCmd], [configGetCmd], and [configSetCmd] as subcommands to [configCmd].
 // These subcommands enable listing, retrieving, and setting configuration values, respectively.
 func init() {
+	configListCmd.Flags().StringVar(&configListFormat, "format", "table", "Output format: table, json")
+	configGetCmd.Flags().BoolVar(&configGetJSON, "json", false, "Output as JSON")
+
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
@@ -80,8 +123,8 @@ func init() {
 
 // runConfigList lists all configuration settings and displays them in a tabular format.
 //
-// This function retrieves the current configuration by merging stored settings with default values.
-// The settings are then formatted into a table and printed to stdout for user visibility.
+// This function retrieves the effective configuration with [config.List], layering environment variables over stored
+// settings over default values. The settings are then formatted into a table and printed to stdout for user visibility.
 //
 //	cmd: Represents the executed [cobra.Command] associated with this function.
 //	args: Contains arguments passed to the command, although they are not used in this function.
@@ -91,14 +134,32 @@ func init() {
 // The displayed table includes columns:
 //   - "Key": The name of the configuration setting.
 //   - "Value": The current value for the configuration key.
+//   - "Source": Where the value comes from: env, stored, or default.
+//
+// With `--format json`, the effective values are written as a single JSON object of keys and values instead of the
+// table.
 func runConfigList(cmd *cobra.Command, args []string) error {
 	settings, err := config.List()
 	if err != nil {
 		return fmt.Errorf("failed to list config: %w", err)
 	}
 
+	switch configListFormat {
+	case "json":
+		values := make(map[string]string, len(settings))
+		for key, setting := range settings {
+			values[key] = setting.Value
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(values)
+	case "table":
+	default:
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", configListFormat)
+	}
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Key", "Value"})
+	table.SetHeader([]string{"Key", "Value", "Source"})
 	table.SetBorder(false)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
@@ -110,8 +171,20 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	table.SetNoWhiteSpace(true)
 
 	for _, key := range config.ValidKeys() {
-		value := settings[key]
-		table.Append([]string{key, value})
+		setting := settings[key]
+		table.Append([]string{key, setting.Value, string(setting.Source)})
+	}
+
+	// Rate keys are per project, so they only exist once set
+	var rateKeys []string
+	for key := range settings {
+		if config.IsRateKey(key) {
+			rateKeys = append(rateKeys, key)
+		}
+	}
+	sort.Strings(rateKeys)
+	for _, key := range rateKeys {
+		table.Append([]string{key, settings[key].Value, string(settings[key].Source)})
 	}
 
 	table.Render()
@@ -125,7 +198,8 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 // associated with the key using [config.Get]. If retrieving the value fails, the function returns
 // an error describing the failure.
 //
-// The retrieved value is printed to the standard output upon success.
+// The retrieved value is printed to the standard output upon success. With `--json`, it is printed as a
+// single-key JSON object (e.g. `{"output.format": "table"}`).
 //
 //   - cmd: Command that triggered the invocation.
 //   - args: Arguments passed to the command, where the first argument is the configuration key.
@@ -139,12 +213,25 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown config key: %s\nValid keys: %s",
 			key, strings.Join(config.ValidKeys(), ", "))
 	}
+	if config.IsRateKey(key) {
+		resolved, err := config.ResolveRateKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project alias: %w", err)
+		}
+		key = resolved
+	}
 
 	value, err := config.Get(key)
 	if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
+	if configGetJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]string{key: value})
+	}
+
 	fmt.Println(value)
 	return nil
 }
@@ -170,12 +257,59 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown config key: %s\nValid keys: %s",
 			key, strings.Join(config.ValidKeys(), ", "))
 	}
+	if config.IsRateKey(key) {
+		resolved, err := config.ResolveRateKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project alias: %w", err)
+		}
+		key = resolved
+	}
 
 	// Validate values for known keys
 	switch key {
 	case config.KeyOutputFormat:
-		if value != "table" && value != "json" && value != "csv" {
-			return fmt.Errorf("value must be 'table', 'json', or 'csv'")
+		if !slices.Contains(outputFormats, value) {
+			return fmt.Errorf("value must be one of: %s", strings.Join(outputFormats, ", "))
+		}
+	case config.KeyStartAutoStopPrevious, config.KeyDeleteDefaultYes, config.KeyDeleteRequireTypedConfirmation,
+		config.KeySleepDetection:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("value must be 'true' or 'false'")
+		}
+	case config.KeyDataLocation, config.KeyReportTagSeparator:
+		if value == "" {
+			return fmt.Errorf("value must not be empty")
+		}
+	case config.KeyReportRounding:
+		if _, err := parseRounding(value); err != nil {
+			return err
+		}
+	case config.KeyReportWeekStart:
+		if value != "monday" && value != "sunday" {
+			return fmt.Errorf("value must be 'monday' or 'sunday'")
+		}
+	case config.KeyReportDefaultPeriod:
+		if value != "menu" && !service.IsValidPeriod(service.Period(value)) {
+			return fmt.Errorf("value must be 'menu' or one of: %v", service.AllPeriods)
+		}
+	case config.KeyGoalDaily, config.KeyGoalWeekly:
+		if _, err := parseGoal(value); err != nil {
+			return err
+		}
+	case config.KeyIdleThreshold, config.KeyMaxSession:
+		if d, err := time.ParseDuration(value); value != "" && value != "0" && (err != nil || d < 0) {
+			return fmt.Errorf("value must be a duration like 15m, or empty to turn it off")
+		}
+	case config.KeyTimezone:
+		if _, err := time.LoadLocation(value); err != nil {
+			return fmt.Errorf("unknown time zone %q (use an IANA name like America/New_York)", value)
+		}
+	default:
+		if config.IsRateKey(key) {
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 {
+				return fmt.Errorf("value must be a non-negative number")
+			}
 		}
 	}
 
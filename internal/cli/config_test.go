@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thinktide/tally/internal/db"
+)
+
+func TestConfigDataLocationUsesDefaultDatabase(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	relocated := t.TempDir()
+
+	// The first relocation is made from the default database
+	if err := db.SetDataLocation(relocated); err != nil {
+		t.Fatal(err)
+	}
+	if dir, err := db.GetDataDir(); err != nil || dir != relocated {
+		t.Fatalf("GetDataDir() = %q, %v, want %q", dir, err, relocated)
+	}
+	openTestDB(t)
+
+	get := func() string {
+		t.Helper()
+		var err error
+		stdout, _ := captureOutput(t, func() { err = runConfigGet(configGetCmd, []string{"data.location"}) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(stdout)
+	}
+
+	if got := get(); got != relocated {
+		t.Errorf("config get data.location = %q, want %q", got, relocated)
+	}
+
+	// Changing it again while the relocated database is open must still take effect
+	moved := filepath.Join(home, "elsewhere")
+	var err error
+	captureOutput(t, func() { err = runConfigSet(configSetCmd, []string{"data.location", moved}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location, err := db.DataLocation(); err != nil || location != moved {
+		t.Errorf("default database has data.location = %q, %v, want %q", location, err, moved)
+	}
+	if got := get(); got != moved {
+		t.Errorf("config get data.location = %q, want %q", got, moved)
+	}
+	if value, err := db.GetConfig("data.location"); err != nil || value != "" {
+		t.Errorf("relocated database has data.location = %q, %v, want it unset", value, err)
+	}
+}
+
+func TestConfigRateResolvesAlias(t *testing.T) {
+	openTestDB(t)
+	project, err := db.GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetProjectAlias("w", project.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _ := captureOutput(t, func() { err = runConfigSet(configSetCmd, []string{"rate.@w", "120"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "rate.@work = 120\n"; stdout != want {
+		t.Errorf("config set printed %q, want %q", stdout, want)
+	}
+	if value, err := db.GetConfig("rate.@work"); err != nil || value != "120" {
+		t.Errorf("rate.@work = %q, %v, want 120", value, err)
+	}
+	if value, err := db.GetConfig("rate.@w"); err != nil || value != "" {
+		t.Errorf("rate.@w = %q, %v, want it unset", value, err)
+	}
+
+	stdout, _ = captureOutput(t, func() { err = runConfigGet(configGetCmd, []string{"rate.@w"}) })
+	if err != nil || strings.TrimSpace(stdout) != "120" {
+		t.Errorf("config get rate.@w = %q, %v, want 120", stdout, err)
+	}
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// projectFileName is the name of the file that sets the project of a directory and its subdirectories for
+// [continueCmd].
+const projectFileName = ".tally"
+
+// projectEnvVar names the environment variable that sets the project for [continueCmd], taking precedence over a
+// [projectFileName] file.
+const projectEnvVar = "TALLY_PROJECT"
+
+// continueFrom specifies the time the continued timer starts at, instead of now.
+var continueFrom string
+
+// continueCmd resumes the last task of the project for the current directory, so `@project` doesn't have to be typed
+// in a repository that is always tracked against the same project.
+var continueCmd = &cobra.Command{
+	Use:   "continue",
+	Short: "Resume the last task of the current directory's project",
+	Long: `Resume the last task of the project for the current directory, like
+'tally resume @project'.
+
+The project is read from the TALLY_PROJECT environment variable or, if it
+is not set, from the first line of a .tally file in the current directory
+or the nearest parent directory that has one, e.g.:
+
+  echo @work > ~/src/work-repo/.tally
+
+If the project's timer is already running, it is left alone; if it is
+paused, it is resumed.
+
+Examples:
+  tally continue            # Continue the last task of this directory's project
+  tally continue -f 09:00   # ... starting at 9am`,
+	Args: cobra.NoArgs,
+	RunE: runContinue,
+}
+
+func init() {
+	continueCmd.Flags().StringVarP(&continueFrom, "from", "f", "", "Start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+}
+
+// runContinue resumes the project found by [contextProject] with [resumeProject], unless its timer is already
+// active.
+func runContinue(cmd *cobra.Command, args []string) error {
+	startTime := db.Now()
+	if continueFrom != "" {
+		var err error
+		if startTime, err = parseTimeInput(continueFrom); err != nil {
+			return err
+		}
+	}
+	cmd.SilenceUsage = true
+
+	projectName, source, err := contextProject()
+	if err != nil {
+		return err
+	}
+	if projectName == "" {
+		return fmt.Errorf("no project for this directory (add a %s file naming the project, or set %s)",
+			projectFileName, projectEnvVar)
+	}
+	fmt.Printf("Project @%s (from %s)\n", projectName, source)
+
+	project, err := db.GetProjectByName(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if project != nil {
+		running, err := db.GetRunningEntry()
+		if err != nil {
+			return fmt.Errorf("failed to get running entry: %w", err)
+		}
+		if running != nil && running.ProjectID == project.ID {
+			if running.Status == model.StatusRunning {
+				fmt.Println("Timer is already running")
+				printStatus(os.Stdout, running)
+				return nil
+			}
+			return resumeDefault(startTime)
+		}
+	}
+
+	return resumeProject(projectName, startTime)
+}
+
+// contextProject returns the project for the current directory without its "@" prefix, and where it came from: the
+// [projectEnvVar] environment variable, or the path of the nearest [projectFileName] file found by
+// [findProjectFile].
+//
+// Returns an empty name if neither is set, or an error if the working directory or the file cannot be read.
+func contextProject() (name, source string, err error) {
+	if value := strings.TrimSpace(os.Getenv(projectEnvVar)); value != "" {
+		return strings.TrimPrefix(value, "@"), projectEnvVar, nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	path := findProjectFile(dir)
+	if path == "" {
+		return "", "", nil
+	}
+
+	name, err = readProjectFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("%s does not name a project", path)
+	}
+	return name, path, nil
+}
+
+// findProjectFile returns the path of the [projectFileName] file in dir or its nearest ancestor that has one, or an
+// empty string if there is none.
+//
+// Only regular files count, so the ~/.tally data directory is skipped.
+func findProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, projectFileName)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// readProjectFile returns the project named on the first line of the file at path that is neither blank nor a "#"
+// comment, without its "@" prefix.
+func readProjectFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.TrimPrefix(line, "@"), nil
+	}
+	return "", scanner.Err()
+}
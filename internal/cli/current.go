@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// currentFormat is a template for the line printed by [currentCmd]. Empty selects the default layout.
+var currentFormat string
+
+// errNoTimer is returned by [currentCmd] when no timer is active, so the process exits with status 1 without printing
+// anything.
+var errNoTimer = errors.New("no timer running")
+
+// currentCmd prints the active timer on a single line, for shell prompts and status bars.
+//
+// Unlike [statusCmd], it prints nothing and exits with status 1 when no timer is active, so prompts can branch on it.
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the current timer on one line",
+	Long: `Print the running or paused timer on one line, e.g. "@work: Fixing bugs (1h02m)".
+
+Prints nothing and exits with status 1 when no timer is active.
+
+--format customizes the line with these fields:
+  {project}  Project name, without @
+  {title}    Entry title
+  {tags}     Tags, e.g. "+backend +api"
+  {elapsed}  Time tracked so far, e.g. 1h02m
+  {status}   running or paused
+
+Examples:
+  tally current
+  tally current --format '{project} {elapsed}'
+  PS1='$(tally current --format "[{project} {elapsed}] ")\$ '`,
+	Args: cobra.NoArgs,
+	RunE: runCurrent,
+}
+
+func init() {
+	currentCmd.Flags().StringVar(&currentFormat, "format", "", "Template using {project}, {title}, {tags}, {elapsed}, {status}")
+}
+
+func runCurrent(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	entry, err := db.GetRunningEntry()
+	if err != nil {
+		return fmt.Errorf("failed to get running entry: %w", err)
+	}
+	if entry == nil {
+		cmd.SilenceErrors = true
+		return errNoTimer
+	}
+
+	fmt.Println(formatCurrent(entry, currentFormat))
+	return nil
+}
+
+// formatCurrent renders entry as a single line using format, or the default layout
+// "@project: title (elapsed)" when format is empty. Paused entries get ", paused" inside the parentheses in the
+// default layout.
+func formatCurrent(entry *model.Entry, format string) string {
+	elapsed := formatDurationCompact(entry.Duration())
+
+	if format == "" {
+		line := "@" + entry.Project.Name
+		if entry.Title != "" {
+			line += ": " + entry.Title
+		}
+		if entry.Status == model.StatusPaused {
+			return fmt.Sprintf("%s (%s, paused)", line, elapsed)
+		}
+		return fmt.Sprintf("%s (%s)", line, elapsed)
+	}
+
+	return strings.NewReplacer(
+		"{project}", entry.Project.Name,
+		"{title}", entry.Title,
+		"{tags}", formatTagsFromModel(entry.Tags),
+		"{elapsed}", elapsed,
+		"{status}", string(entry.Status),
+	).Replace(format)
+}
+
+// formatDurationCompact formats d rounded down to minutes without spaces, e.g. "1h02m" or "45m", to keep prompts
+// short.
+func formatDurationCompact(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	h := d / time.Hour
+	m := (d - h*time.Hour) / time.Minute
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
@@ -1,21 +1,25 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
 )
 
 // deleteForce specifies whether the delete operation should skip the user confirmation prompt.
-var deleteForce bool
+//
+// deleteLast deletes the most recent entry instead of offering a choice when no ID is given.
+var (
+	deleteForce bool
+	deleteLast  bool
+)
 
 // deleteCmd represents the command to delete a time entry.
 //
-// Deletes a specific time entry when provided with an ID. Without an ID, it deletes the most recent entry.
+// Deletes a specific time entry when provided with an ID. Without an ID, the entry is chosen with [selectEntry], or the
+// most recent entry is deleted with --last.
 //
 // The command performs a confirmation prompt before deletion unless the --force flag is used to bypass it.
 //
@@ -26,10 +30,12 @@ var deleteForce bool
 var deleteCmd = &cobra.Command{
 	Use:   "delete [id]",
 	Short: "Delete a time entry",
-	Long: `Delete a time entry. Without an ID, deletes the most recent entry.
+	Long: `Delete a time entry. Without an ID, lists the recent entries to
+choose from (or picks the most recent one when not run from a terminal).
 
 Examples:
-  tally delete                              # Delete most recent entry
+  tally delete                              # Choose from recent entries
+  tally delete --last                       # Delete most recent entry
   tally delete 01ABC123DEF456GHI789JKL0     # Delete specific entry
   tally delete --force                      # Skip confirmation`,
 	Args: cobra.MaximumNArgs(1),
@@ -40,17 +46,21 @@ Examples:
 //
 // This function configures the flags for the "delete" command, adding the "force" flag (`-f`) to bypass confirmation prompts.
 // The "force" flag is bound to the `deleteForce` variable to control whether user confirmation is required.
+// The "last" flag, bound to `deleteLast`, deletes the most recent entry instead of listing recent entries to choose from.
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().BoolVar(&deleteLast, "last", false, "Delete the most recent entry without asking which")
 }
 
 // runDelete deletes a specified time entry or the most recent one if no ID is provided.
 // It retrieves the entry details, displays them to confirm the deletion, and allows the user to cancel unless forced.
 //
-// If no arguments are passed, runDelete will fetch the most recent entry using [db.GetLastEntry].
+// If no arguments are passed, runDelete lets the user pick an entry with [selectEntry], which falls back to the most
+// recent entry.
 // If an entry ID is provided through args, it will fetch that specific entry using [db.GetEntryByID].
 //
-// The function prompts for confirmation before deletion unless `deleteForce` is set to true. After confirmation, it
+// The function prompts for confirmation before deletion unless `deleteForce` is set to true. The prompt is chosen by
+// [confirmDelete] based on configuration. After confirmation, it
 // deletes the entry using [db.DeleteEntry] and provides feedback to indicate whether the deletion was successful.
 //
 //	cmd: Represents the Cobra command invoked by the user.
@@ -61,20 +71,28 @@ func init() {
 //   - The entry ID does not exist in the database.
 //   - Errors occur during entry deletion.
 func runDelete(cmd *cobra.Command, args []string) error {
+	if deleteLast && len(args) > 0 {
+		return fmt.Errorf("--last cannot be combined with an entry ID")
+	}
+
 	var entryID string
 
 	if len(args) == 0 {
-		entry, err := db.GetLastEntry()
+		var err error
+		entryID, err = selectEntry("delete", deleteLast)
 		if err != nil {
-			return fmt.Errorf("failed to get last entry: %w", err)
+			return err
 		}
-		if entry == nil {
+		if entryID == "" {
 			fmt.Println("No entries to delete")
 			return nil
 		}
-		entryID = entry.ID
 	} else {
-		entryID = args[0]
+		var err error
+		entryID, err = db.ResolveEntryID(args[0])
+		if err != nil {
+			return err
+		}
 	}
 
 	entry, err := db.GetEntryByID(entryID)
@@ -94,14 +112,11 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	// Confirm deletion unless --force
 	if !deleteForce {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Delete this entry? [y/N]: ")
-		input, err := reader.ReadString('\n')
+		ok, err := confirmDelete("Delete this entry?", entry.Project.Name)
 		if err != nil {
 			return err
 		}
-		input = strings.TrimSpace(strings.ToLower(input))
-		if input != "y" && input != "yes" {
+		if !ok {
 			fmt.Println("Cancelled")
 			return nil
 		}
@@ -114,3 +129,25 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	fmt.Println("Entry deleted")
 	return nil
 }
+
+// confirmDelete asks the user to confirm a deletion, honoring the delete confirmation settings.
+//
+// If [config.KeyDeleteRequireTypedConfirmation] is enabled, the user must type projectName to confirm. Otherwise a
+// yes/no prompt is shown, defaulting to yes when [config.KeyDeleteDefaultYes] is enabled.
+//
+// Returns whether the deletion was confirmed, or an error if reading the configuration or stdin fails.
+func confirmDelete(question, projectName string) (bool, error) {
+	typed, err := config.GetBool(config.KeyDeleteRequireTypedConfirmation)
+	if err != nil {
+		return false, err
+	}
+	if typed {
+		return confirmTyped(question, projectName)
+	}
+
+	defaultYes, err := config.GetBool(config.KeyDeleteDefaultYes)
+	if err != nil {
+		return false, err
+	}
+	return confirm(question, defaultYes)
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// doctorFix specifies whether [doctorCmd] repairs the problems it finds.
+var doctorFix bool
+
+// doctorCmd scans the database for rows that break its integrity, such as pauses of deleted entries, using
+// [db.IntegrityChecks].
+//
+// It complements [verifyCmd], which checks the duration and pause math of entries that are otherwise intact.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the database for orphaned and inconsistent data",
+	Long: `Scan the database for integrity problems left behind by deletions or
+manual edits:
+
+  - pauses of deleted entries
+  - tag links to deleted entries or tags
+  - entries ending before they start
+  - open pauses on stopped entries
+
+With --fix, orphaned rows are deleted, end times are clamped to the start
+time, and open pauses are closed at their entry's end time. Run
+'tally verify' afterwards to check the pauses of the remaining entries.
+
+Examples:
+  tally doctor         # Report problems
+  tally doctor --fix   # Repair them`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+// init configures flags for the [doctorCmd] command.
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair the problems found")
+}
+
+// runDoctor runs every check in [db.IntegrityChecks], printing the number of problems each finds and, with --fix,
+// repairing them.
+//
+// Returns an error if a check or a repair fails.
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	found, fixed := 0, 0
+	for _, check := range db.IntegrityChecks {
+		n, err := check.Count()
+		if err != nil {
+			return fmt.Errorf("failed to check %s: %w", check.Problem, err)
+		}
+		if n == 0 {
+			continue
+		}
+		found += n
+		fmt.Printf("%d %s\n", n, check.Problem)
+
+		if !doctorFix {
+			continue
+		}
+		repaired, err := check.Fix()
+		if err != nil {
+			return fmt.Errorf("failed to repair %s: %w", check.Problem, err)
+		}
+		fixed += repaired
+		fmt.Printf("  Fixed: %d %s\n", repaired, check.Repair)
+	}
+
+	if found == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+
+	fmt.Println()
+	if doctorFix {
+		fmt.Printf("Problems found: %d, fixed: %d\n", found, fixed)
+	} else {
+		fmt.Printf("Problems found: %d (run with --fix to repair them)\n", found)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// duplicateFrom specifies a custom start time for the timer started by [duplicateCmd].
+var duplicateFrom string
+
+// duplicateCmd starts a new timer with the project, title, and tags of an existing entry.
+//
+// Like [startCmd] without auto-stop, it refuses to start while another timer is running.
+var duplicateCmd = &cobra.Command{
+	Use:   "duplicate <id>",
+	Short: "Start a new timer copying an existing entry",
+	Long: `Start a new timer with the same project, title, and tags as an existing entry.
+
+The ID may be shortened to any unique prefix.
+
+Examples:
+  tally duplicate 01JQXYZ123           # Start now
+  tally duplicate 01JQ -f 09:00        # Start at 9am`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDuplicate,
+}
+
+func init() {
+	duplicateCmd.Flags().StringVarP(&duplicateFrom, "from", "f", "", "Start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+}
+
+func runDuplicate(cmd *cobra.Command, args []string) error {
+	startTime := db.Now()
+	if duplicateFrom != "" {
+		t, err := parseTimeInput(duplicateFrom)
+		if err != nil {
+			return err
+		}
+		if t.After(startTime) {
+			return fmt.Errorf("start time cannot be in the future")
+		}
+		startTime = t
+	}
+	cmd.SilenceUsage = true
+
+	running, err := db.GetRunningEntry()
+	if err != nil {
+		return fmt.Errorf("failed to check running entry: %w", err)
+	}
+	if running != nil {
+		fmt.Println("Timer already running:")
+		printStatus(os.Stdout, running)
+		return nil
+	}
+
+	entryID, err := db.ResolveEntryID(args[0])
+	if err != nil {
+		return err
+	}
+	source, err := db.GetEntryByID(entryID)
+	if err != nil {
+		return fmt.Errorf("entry not found: %w", err)
+	}
+
+	tagIDs := make([]string, len(source.Tags))
+	for i, t := range source.Tags {
+		tagIDs[i] = t.ID
+	}
+
+	entry, err := db.CreateEntryAt(source.ProjectID, source.Title, tagIDs, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to create entry: %w", err)
+	}
+
+	fmt.Printf("Started @%s", entry.Project.Name)
+	if entry.Title != "" {
+		fmt.Printf(": %s", entry.Title)
+	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf(" %s", formatTagsFromModel(entry.Tags))
+	}
+	fmt.Println()
+	return nil
+}
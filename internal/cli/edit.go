@@ -5,18 +5,20 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
 )
 
 // editCmd provides functionality to edit a time entry in the user's default editor (defaults to vim) as a JSON file.
 //
-// The command can edit either the most recent entry or a specified entry by its ID.
+// The command can edit either a specified entry by its ID or one picked from the recent entries.
 // It accepts at most one argument, which is the ID of the entry to be edited.
-// If no ID is provided, the most recent entry is edited.
+// If no ID is provided, the entry is chosen with [selectEntry], or the most recent entry is edited with --last.
 //
 // The editor to be used is determined using the $EDITOR environment variable.
 // If the $EDITOR variable is not set, "vim" is used as the default editor.
@@ -26,17 +28,57 @@ import (
 var editCmd = &cobra.Command{
 	Use:   "edit [id]",
 	Short: "Edit a time entry",
-	Long: `Edit a time entry in your editor. Without an ID, edits the most recent entry.
+	Long: `Edit a time entry in your editor. Without an ID, lists the recent
+entries to choose from (or edits the most recent one when not run from
+a terminal).
 
 Examples:
-  tally edit        # Edit most recent entry
+  tally edit        # Choose from recent entries
+  tally edit --last # Edit most recent entry
   tally edit 42     # Edit entry with ID 42
+  tally edit 01JQ --title "Code review" --add-tag +review
+  tally edit 01JQ --project @work --start 09:00 --end 10:30
+  tally edit 01JQ --estimate 3h    # --estimate "" removes it
 
-Opens the entry as JSON in $EDITOR (defaults to vim).`,
+Opens the entry as JSON in $EDITOR (defaults to vim). With any of the
+field flags, only those fields are changed and no editor is opened.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runEdit,
 }
 
+// editProject, editTitle, editStart, and editEnd set the corresponding field of the entry without opening an editor.
+//
+// editAddTags and editRemoveTags add tags to and remove tags from the entry without opening an editor.
+//
+// editEstimate sets the estimated duration of the entry without opening an editor; an empty value removes it.
+//
+// editLast edits the most recent entry instead of offering a choice when no ID is given.
+var (
+	editProject    string
+	editTitle      string
+	editStart      string
+	editEnd        string
+	editEstimate   string
+	editAddTags    []string
+	editRemoveTags []string
+	editLast       bool
+)
+
+// editFieldFlags lists the flags that switch [editCmd] to non-interactive mode.
+var editFieldFlags = []string{"project", "title", "start", "end", "estimate", "add-tag", "remove-tag"}
+
+// init configures the field flags of the [editCmd] command.
+func init() {
+	editCmd.Flags().StringVar(&editProject, "project", "", "Move the entry to this @project")
+	editCmd.Flags().StringVar(&editTitle, "title", "", "Set the title")
+	editCmd.Flags().StringVar(&editStart, "start", "", "Set the start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	editCmd.Flags().StringVar(&editEnd, "end", "", "Set the end time of a stopped entry (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	editCmd.Flags().StringVar(&editEstimate, "estimate", "", "Set the estimated duration, e.g. 2h (empty to remove it)")
+	editCmd.Flags().StringSliceVar(&editAddTags, "add-tag", nil, "Add a +tag (repeatable)")
+	editCmd.Flags().StringSliceVar(&editRemoveTags, "remove-tag", nil, "Remove a +tag (repeatable)")
+	editCmd.Flags().BoolVar(&editLast, "last", false, "Edit the most recent entry without asking")
+}
+
 // editableEntry represents an entry that can be modified with enriched details about its state and associated metadata.
 //
 // This type includes fields to store information such as the entry's ID, associated project, title, tags, time durations,
@@ -49,6 +91,10 @@ Opens the entry as JSON in $EDITOR (defaults to vim).`,
 //   - ID: The unique identifier for the entry.
 //   - Project: The name of the project the entry is associated with.
 //   - Title: A short description of the entry.
+//   - Note: An optional longer note about the entry.
+//   - Billable: Whether the entry is billable. When it is missing from the edited JSON, the entry keeps its value.
+//   - Estimate: The estimated duration, such as "2h0m0s", or empty for none. When it is missing from the edited JSON,
+//     the entry keeps its estimate.
 //   - Tags: A list of tags categorizing the entry.
 //   - StartTime: The starting time of the entry in a formatted string (e.g., "2006-01-02 15:04:05").
 //   - EndTime: The optional ending time of the entry in a formatted string (if available).
@@ -58,6 +104,9 @@ type editableEntry struct {
 	ID        string      `json:"id"`
 	Project   string      `json:"project"`
 	Title     string      `json:"title"`
+	Note      string      `json:"note"`
+	Billable  *bool       `json:"billable"`
+	Estimate  *string     `json:"estimate"`
 	Tags      []string    `json:"tags"`
 	StartTime string      `json:"start_time"`
 	EndTime   string      `json:"end_time,omitempty"`
@@ -83,7 +132,7 @@ type editPause struct {
 
 // runEdit edits an existing entry identified by its ID or the most recent entry if no ID is provided.
 //
-// If no arguments are passed, the function attempts to retrieve the most recent entry from the database.
+// If no arguments are passed, the entry is picked with [selectEntry], which falls back to the most recent entry.
 // The user edits the entry details in a temporary file using the editor specified by the "EDITOR" environment variable.
 // If the editor is not defined, "vim" is used as the default. Updates are validated and saved back to the database.
 //
@@ -98,20 +147,28 @@ type editPause struct {
 //
 // Validation errors, like invalid timestamps or inconsistent pause information, will also result in returned errors.
 func runEdit(cmd *cobra.Command, args []string) error {
+	if editLast && len(args) > 0 {
+		return fmt.Errorf("--last cannot be combined with an entry ID")
+	}
+
 	var entryID string
 
 	if len(args) == 0 {
-		entry, err := db.GetLastEntry()
+		var err error
+		entryID, err = selectEntry("edit", editLast)
 		if err != nil {
-			return fmt.Errorf("failed to get last entry: %w", err)
+			return err
 		}
-		if entry == nil {
+		if entryID == "" {
 			fmt.Println("No entries to edit")
 			return nil
 		}
-		entryID = entry.ID
 	} else {
-		entryID = args[0]
+		var err error
+		entryID, err = db.ResolveEntryID(args[0])
+		if err != nil {
+			return err
+		}
 	}
 
 	entry, err := db.GetEntryByID(entryID)
@@ -119,6 +176,13 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("entry not found: %w", err)
 	}
 
+	for _, name := range editFieldFlags {
+		if cmd.Flags().Changed(name) {
+			cmd.SilenceUsage = true
+			return editFields(cmd, entry)
+		}
+	}
+
 	// Build editable structure
 	tags := make([]string, len(entry.Tags))
 	for i, t := range entry.Tags {
@@ -129,6 +193,8 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		ID:        entry.ID,
 		Project:   entry.Project.Name,
 		Title:     entry.Title,
+		Note:      entry.Note,
+		Billable:  &entry.Billable,
 		Tags:      tags,
 		StartTime: entry.StartTime.Format("2006-01-02 15:04:05"),
 		Status:    string(entry.Status),
@@ -138,6 +204,12 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		editable.EndTime = entry.EndTime.Format("2006-01-02 15:04:05")
 	}
 
+	estimate := ""
+	if entry.Estimate != nil {
+		estimate = entry.Estimate.String()
+	}
+	editable.Estimate = &estimate
+
 	for _, p := range entry.Pauses {
 		ep := editPause{
 			ID:        p.ID,
@@ -194,14 +266,14 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Parse times
-	startTime, err := time.ParseInLocation("2006-01-02 15:04:05", updated.StartTime, time.Local)
+	startTime, err := time.ParseInLocation("2006-01-02 15:04:05", updated.StartTime, db.Location)
 	if err != nil {
 		return fmt.Errorf("invalid start_time format: %w", err)
 	}
 
 	var endTime *time.Time
 	if updated.EndTime != "" {
-		t, err := time.ParseInLocation("2006-01-02 15:04:05", updated.EndTime, time.Local)
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", updated.EndTime, db.Location)
 		if err != nil {
 			return fmt.Errorf("invalid end_time format: %w", err)
 		}
@@ -212,6 +284,15 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	if endTime != nil && endTime.Before(startTime) {
 		return fmt.Errorf("end_time cannot be before start_time")
 	}
+	openPauses := 0
+	for _, p := range updated.Pauses {
+		if p.ResumeTime == "" {
+			openPauses++
+		}
+	}
+	if openPauses > 1 {
+		return fmt.Errorf("only one pause can be open at a time (%d have no resume_time)", openPauses)
+	}
 
 	// Get or create project
 	project, err := db.GetOrCreateProject(updated.Project)
@@ -233,60 +314,177 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		tagIDs = append(tagIDs, tag.ID)
 	}
 
-	// Update entry
-	if err := db.UpdateEntry(entryID, project.ID, updated.Title, &startTime, endTime, tagIDs); err != nil {
-		return fmt.Errorf("failed to update entry: %w", err)
+	billable := entry.Billable
+	if updated.Billable != nil {
+		billable = *updated.Billable
 	}
 
-	// Handle pauses - build map of existing pause IDs
-	existingPauses := make(map[string]bool)
-	for _, p := range entry.Pauses {
-		existingPauses[p.ID] = true
+	newEstimate := entry.Estimate
+	if updated.Estimate != nil {
+		if newEstimate, err = parseEstimate(*updated.Estimate); err != nil {
+			return err
+		}
 	}
 
-	// Update or track pauses from edited JSON
-	updatedPauses := make(map[string]bool)
+	// Parse the edited pauses before changing anything
+	type parsedPause struct {
+		editPause
+		pauseTime  time.Time
+		resumeTime *time.Time
+	}
+	var pauses []parsedPause
+	keptPauses := make(map[string]bool)
 	for _, p := range updated.Pauses {
-		pauseTime, err := time.ParseInLocation("2006-01-02 15:04:05", p.PauseTime, time.Local)
+		pauseTime, err := time.ParseInLocation("2006-01-02 15:04:05", p.PauseTime, db.Location)
 		if err != nil {
 			return fmt.Errorf("invalid pause_time format: %w", err)
 		}
 
 		var resumeTime *time.Time
 		if p.ResumeTime != "" {
-			t, err := time.ParseInLocation("2006-01-02 15:04:05", p.ResumeTime, time.Local)
+			t, err := time.ParseInLocation("2006-01-02 15:04:05", p.ResumeTime, db.Location)
 			if err != nil {
 				return fmt.Errorf("invalid resume_time format: %w", err)
 			}
 			resumeTime = &t
 		}
 
+		pauses = append(pauses, parsedPause{p, pauseTime, resumeTime})
+		if p.ID != "" {
+			keptPauses[p.ID] = true
+		}
+	}
+
+	// Update entry
+	if err := db.UpdateEntry(entryID, project.ID, updated.Title, updated.Note, billable, &startTime, endTime, tagIDs); err != nil {
+		return fmt.Errorf("failed to update entry: %w", err)
+	}
+	if err := db.SetEntryEstimate(entryID, newEstimate); err != nil {
+		return fmt.Errorf("failed to set estimate: %w", err)
+	}
+
+	// Delete pauses that were removed from JSON, then update the others before creating new ones, so an open pause
+	// that was removed or closed doesn't block a new open pause
+	for _, p := range entry.Pauses {
+		if !keptPauses[p.ID] {
+			if err := db.DeletePause(p.ID); err != nil {
+				return fmt.Errorf("failed to delete pause: %w", err)
+			}
+		}
+	}
+	for _, p := range pauses {
+		if p.ID != "" {
+			if err := db.UpdatePause(p.ID, p.pauseTime, p.resumeTime); err != nil {
+				return fmt.Errorf("failed to update pause: %w", err)
+			}
+		}
+	}
+	for _, p := range pauses {
 		if p.ID == "" {
-			// Create new pause
 			reason := p.Reason
 			if reason == "" {
-				reason = "Manual"
+				reason = defaultPauseReason
 			}
-			_, err := db.CreatePause(entryID, pauseTime, resumeTime, reason)
-			if err != nil {
+			if _, err := db.CreatePause(entryID, p.pauseTime, p.resumeTime, reason); err != nil {
 				return fmt.Errorf("failed to create pause: %w", err)
 			}
-		} else {
-			// Update existing pause
-			updatedPauses[p.ID] = true
-			if err := db.UpdatePause(p.ID, pauseTime, resumeTime); err != nil {
-				return fmt.Errorf("failed to update pause: %w", err)
-			}
 		}
 	}
 
-	// Delete pauses that were removed from JSON
-	for _, p := range entry.Pauses {
-		if !updatedPauses[p.ID] {
-			if err := db.DeletePause(p.ID); err != nil {
-				return fmt.Errorf("failed to delete pause: %w", err)
-			}
+	fmt.Println("Entry updated successfully")
+	return nil
+}
+
+// editFields applies the field flags given on the command line to entry, leaving all other fields unchanged.
+//
+// Times are validated like in the editor: the end must not be before the start. Only stopped entries have an end time
+// to change. Tags that are added are created if needed; removing a tag the entry does not have is an error.
+//
+// Returns an error if a flag value is invalid or the update fails.
+func editFields(cmd *cobra.Command, entry *model.Entry) error {
+	projectID := entry.ProjectID
+	if cmd.Flags().Changed("project") {
+		name := strings.TrimPrefix(editProject, "@")
+		if name == "" {
+			return fmt.Errorf("--project cannot be empty")
+		}
+		project, err := db.GetOrCreateProject(name)
+		if err != nil {
+			return fmt.Errorf("failed to get/create project: %w", err)
+		}
+		projectID = project.ID
+	}
+
+	title := entry.Title
+	if cmd.Flags().Changed("title") {
+		title = editTitle
+	}
+
+	startTime := entry.StartTime
+	if cmd.Flags().Changed("start") {
+		t, err := parseTimeInput(editStart)
+		if err != nil {
+			return err
+		}
+		startTime = t
+	}
+
+	endTime := entry.EndTime
+	if cmd.Flags().Changed("end") {
+		if entry.Status != model.StatusStopped {
+			return fmt.Errorf("entry is still %s; use 'tally stop --at' to set its end time", entry.Status)
+		}
+		t, err := parseTimeInput(editEnd)
+		if err != nil {
+			return err
 		}
+		endTime = &t
+	}
+	if endTime != nil && endTime.Before(startTime) {
+		return fmt.Errorf("end time cannot be before start time")
+	}
+
+	estimate := entry.Estimate
+	if cmd.Flags().Changed("estimate") {
+		var err error
+		if estimate, err = parseEstimate(editEstimate); err != nil {
+			return err
+		}
+	}
+
+	removed := make(map[string]bool)
+	for _, name := range editRemoveTags {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "+")
+		if !hasTag(*entry, name) {
+			return fmt.Errorf("entry does not have tag +%s", name)
+		}
+		removed[name] = true
+	}
+	var tagIDs []string
+	for _, t := range entry.Tags {
+		if !removed[t.Name] {
+			tagIDs = append(tagIDs, t.ID)
+		}
+	}
+	for _, name := range editAddTags {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "+")
+		if name == "" {
+			continue
+		}
+		tag, err := db.GetOrCreateTag(name)
+		if err != nil {
+			return fmt.Errorf("failed to get/create tag: %w", err)
+		}
+		if !slices.Contains(tagIDs, tag.ID) {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+
+	if err := db.UpdateEntry(entry.ID, projectID, title, entry.Note, entry.Billable, &startTime, endTime, tagIDs); err != nil {
+		return fmt.Errorf("failed to update entry: %w", err)
+	}
+	if err := db.SetEntryEstimate(entry.ID, estimate); err != nil {
+		return fmt.Errorf("failed to set estimate: %w", err)
 	}
 
 	fmt.Println("Entry updated successfully")
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// exportFormat selects the export format: "json" or "ics".
+//
+// exportOutput is the file to write to; stdout is used when it is empty.
+//
+// exportFrom and exportTo restrict the export to entries starting within the given dates, inclusive.
+//
+// exportAnyTag includes entries with any of the given tags instead of requiring all of them.
+var (
+	exportFormat string
+	exportOutput string
+	exportFrom   string
+	exportTo     string
+	exportAnyTag bool
+)
+
+// icsTimeFormat is the UTC date-time layout used by iCalendar.
+const icsTimeFormat = "20060102T150405Z"
+
+// exportCmd writes entries to a file or stdout for backup or use in other tools.
+//
+// JSON output contains every field of [model.Entry], including its project, tags, and pauses. ICS output contains one
+// calendar event per stopped entry; running and paused entries have no end time yet and are skipped.
+var exportCmd = &cobra.Command{
+	Use:   "export [@project] [+tag]...",
+	Short: "Export entries to JSON or ICS",
+	Long: `Export entries, optionally filtered by project, tags, and date range.
+
+Formats:
+  json  All entry fields, including project, tags, and pauses
+  ics   One calendar event per stopped entry (running entries are skipped)
+
+Examples:
+  tally export > backup.json                    # Export everything as JSON
+  tally export @work --format ics -o work.ics   # Calendar of @work entries
+  tally export --from 2024-01-01 --to 2024-01-31 -o january.json`,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Output format: json, ics")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to this file instead of stdout")
+	exportCmd.Flags().StringVar(&exportFrom, "from", "", "Start date (YYYY-MM-DD)")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "End date (YYYY-MM-DD)")
+	exportCmd.Flags().BoolVar(&exportAnyTag, "any-tag", false, "Match entries with any of the given tags instead of all")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if exportFormat != "json" && exportFormat != "ics" {
+		return fmt.Errorf("invalid format: %s (use 'json' or 'ics')", exportFormat)
+	}
+	cmd.SilenceUsage = true
+
+	opts, err := parseEntryFilter(args, exportFrom, exportTo)
+	if err != nil {
+		return err
+	}
+	opts.AnyTag = exportAnyTag
+
+	// Entries are written as they are loaded, a page at a time, so large exports are not held in memory
+	exported := 0
+	entries := func(fn func(model.Entry) error) error {
+		return db.EachEntry(opts, func(e model.Entry) error {
+			exported++
+			return fn(e)
+		})
+	}
+
+	var w io.Writer = os.Stdout
+	var out *os.File
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w, out = f, f
+	}
+
+	if exportFormat == "ics" {
+		err = writeICS(w, entries)
+	} else {
+		err = writeEntriesJSON(w, entries)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export entries: %w", err)
+	}
+
+	if out != nil {
+		// The export only counts as written once the file closes without error
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d entries to %s\n", exported, exportOutput)
+	}
+	return nil
+}
+
+// parseEntryFilter builds [db.ListEntriesOptions] from @project and +tag arguments and inclusive YYYY-MM-DD from and to
+// dates, either of which may be empty.
+//
+// Returns an error if an argument is neither a project nor a tag, a project or tag does not exist, or a date is
+// invalid.
+func parseEntryFilter(args []string, from, to string) (db.ListEntriesOptions, error) {
+	var opts db.ListEntriesOptions
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "@") {
+			projectName := strings.TrimPrefix(arg, "@")
+			project, err := db.GetProjectByName(projectName)
+			if err != nil {
+				return opts, fmt.Errorf("failed to get project: %w", err)
+			}
+			if project == nil {
+				return opts, fmt.Errorf("project @%s not found", projectName)
+			}
+			opts.ProjectID = &project.ID
+		} else if strings.HasPrefix(arg, "+") {
+			tagName := strings.TrimPrefix(arg, "+")
+			tag, err := db.GetTagByName(tagName)
+			if err != nil {
+				return opts, fmt.Errorf("failed to get tag: %w", err)
+			}
+			if tag == nil {
+				return opts, fmt.Errorf("tag +%s not found", tagName)
+			}
+			opts.TagIDs = append(opts.TagIDs, tag.ID)
+		} else {
+			return opts, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+
+	if from != "" {
+		t, err := time.ParseInLocation("2006-01-02", from, db.Location)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --from date (use YYYY-MM-DD): %w", err)
+		}
+		opts.From = &t
+	}
+	if to != "" {
+		t, err := time.ParseInLocation("2006-01-02", to, db.Location)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --to date (use YYYY-MM-DD): %w", err)
+		}
+		// Add a day to include the entire 'to' date
+		t = t.AddDate(0, 0, 1)
+		opts.To = &t
+	}
+
+	return opts, nil
+}
+
+// entrySource calls fn for each entry to export, in order, and returns the first error from loading the entries or
+// from fn. [db.EachEntry] with fixed options is one.
+type entrySource func(fn func(model.Entry) error) error
+
+// writeEntriesJSON writes the entries from entries to w as an indented JSON array, encoding one entry at a time.
+func writeEntriesJSON(w io.Writer, entries entrySource) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	n := 0
+	err := entries(func(e model.Entry) error {
+		sep := ","
+		if n == 0 {
+			sep = ""
+		}
+		n++
+		data, err := json.MarshalIndent(e, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n  %s", sep, data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		_, err = io.WriteString(w, "\n]\n")
+		return err
+	}
+	_, err = io.WriteString(w, "]\n")
+	return err
+}
+
+// writeICS writes the entries from entries to w as an iCalendar (RFC 5545) calendar with one VEVENT per stopped entry.
+//
+// The event summary is "@project: title", tags become categories, and the description records the worked duration,
+// which excludes pauses. Entries without an end time are skipped.
+func writeICS(w io.Writer, entries entrySource) error {
+	stamp := time.Now().UTC().Format(icsTimeFormat)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//thinktide//tally//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	if err := writeICSLines(w, lines); err != nil {
+		return err
+	}
+
+	err := entries(func(e model.Entry) error {
+		if e.EndTime == nil {
+			return nil
+		}
+
+		summary := "@" + e.Project.Name
+		if e.Title != "" {
+			summary += ": " + e.Title
+		}
+		description := "Worked: " + formatDuration(e.Duration())
+
+		event := []string{
+			"BEGIN:VEVENT",
+			"UID:" + e.ID + "@tally",
+			"DTSTAMP:" + stamp,
+			"DTSTART:" + e.StartTime.UTC().Format(icsTimeFormat),
+			"DTEND:" + e.EndTime.UTC().Format(icsTimeFormat),
+			"SUMMARY:" + escapeICS(summary),
+			"DESCRIPTION:" + escapeICS(description),
+		}
+		if len(e.Tags) > 0 {
+			categories := make([]string, len(e.Tags))
+			for i, t := range e.Tags {
+				categories[i] = escapeICS(t.Name)
+			}
+			event = append(event, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		event = append(event, "END:VEVENT")
+
+		return writeICSLines(w, event)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeICSLines(w, []string{"END:VCALENDAR"})
+}
+
+// writeICSLines writes each line with CRLF endings, folding lines longer than 75 octets as RFC 5545 requires.
+func writeICSLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		limit := 75
+		for len(line) > limit {
+			cut := limit
+			// Don't split a multi-byte UTF-8 sequence
+			for cut > 0 && line[cut]&0xC0 == 0x80 {
+				cut--
+			}
+			if _, err := io.WriteString(w, line[:cut]+"\r\n "); err != nil {
+				return err
+			}
+			line = line[cut:]
+			// Continuation lines start with a space, which counts toward the limit
+			limit = 74
+		}
+		if _, err := io.WriteString(w, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeICS escapes text for use in an iCalendar TEXT value.
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/service"
+)
+
+// gapsMin defines the minimum length of an untracked span for it to be listed by [gapsCmd].
+var gapsMin time.Duration
+
+// gapsCmd lists spans of untracked time between consecutive entries within a period.
+//
+// The period defaults to "today" and accepts the same values as the report command. Only gaps within a single
+// calendar day are listed, and gaps shorter than --min are ignored.
+var gapsCmd = &cobra.Command{
+	Use:   "gaps [period]",
+	Short: "Show untracked time between entries",
+	Long: `Show untracked time between consecutive entries.
+
+Periods:
+  today, yesterday, week, lastWeek, month, lastMonth, year, lastYear
+
+Examples:
+  tally gaps                  # Today's gaps of 15 minutes or more
+  tally gaps yesterday        # Yesterday's gaps
+  tally gaps week --min 30m   # This week's gaps of 30 minutes or more`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGaps,
+}
+
+// init configures flags for the [gapsCmd] command.
+func init() {
+	gapsCmd.Flags().DurationVar(&gapsMin, "min", 15*time.Minute, "Minimum gap length to show")
+}
+
+// runGaps finds and prints untracked gaps for the requested period.
+//
+// Each gap is shown with its start and end time and duration, followed by the total untracked time.
+//
+// Returns an error if the period is invalid or if the entries cannot be loaded.
+func runGaps(cmd *cobra.Command, args []string) error {
+	period := service.PeriodToday
+	if len(args) == 1 {
+		period = service.Period(args[0])
+	}
+	if !service.IsValidPeriod(period) {
+		return fmt.Errorf("invalid period: %s\nValid periods: %v", period, service.AllPeriods)
+	}
+
+	gaps, err := service.FindGaps(period, gapsMin)
+	if err != nil {
+		return fmt.Errorf("failed to find gaps: %w", err)
+	}
+
+	if len(gaps) == 0 {
+		fmt.Println("No gaps found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Date", "From", "To", "Duration"})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	var total time.Duration
+	for _, g := range gaps {
+		total += g.Duration
+		table.Append([]string{
+			g.Start.Format("2006-01-02"),
+			g.Start.Format("15:04"),
+			g.End.Format("15:04"),
+			formatDurationShort(g.Duration),
+		})
+	}
+
+	table.Render()
+	fmt.Printf("\nUntracked: %s\n", formatDurationShort(total))
+	return nil
+}
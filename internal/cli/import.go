@@ -0,0 +1,463 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// importFormat selects the format of the file read by [importCmd].
+//
+// importDryRun specifies whether [importCmd] only prints what it would import, without writing to the database.
+//
+// importAllowOverlap specifies whether records overlapping existing entries are imported instead of skipped.
+var (
+	importFormat       string
+	importDryRun       bool
+	importAllowOverlap bool
+)
+
+// importCmd creates completed entries from another time tracker's export, or restores entries exported by
+// `tally export` (see [importTallyJSON]).
+//
+// Each importer parses its file into [importRecord] values; rows that cannot be parsed are reported on stderr and
+// skipped rather than aborting the whole import. Records overlapping an existing entry are skipped the same way unless
+// --allow-overlap is set. Each record carries a source ID, and records whose source ID was already imported are skipped,
+// so importing the same file again only adds what is new.
+var importCmd = &cobra.Command{
+	Use:   "import <file> --format <format>",
+	Short: "Import entries from another time tracker or a tally export",
+	Long: `Import completed entries from another time tracker's export, or restore
+entries from 'tally export'.
+
+Formats:
+  toggl       Toggl Track "Detailed" CSV export
+  clockify    Clockify "Detailed report" CSV export
+  tally-json  JSON written by 'tally export', restored with IDs, statuses,
+              notes, billing fields, estimates, tags, and pauses
+
+Projects and tags are created as needed. Rows that cannot be parsed, or that
+overlap an existing entry, are skipped with a warning. Use --allow-overlap to
+import overlapping rows anyway. Rows imported before are skipped, so the same
+export can be imported again safely.
+
+Examples:
+  tally import --format toggl toggl.csv --dry-run   # Preview the import
+  tally import --format toggl toggl.csv
+  tally import --format clockify clockify.csv
+  tally import --format tally-json backup.json      # Restore a backup`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Format of the file: toggl, clockify, tally-json")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without saving anything")
+	importCmd.Flags().BoolVar(&importAllowOverlap, "allow-overlap", false, "Import entries that overlap existing ones")
+}
+
+// importRecord is a single completed entry parsed from an import file.
+//
+// sourceID identifies the record in its source tool and is stored with the entry to make re-imports idempotent.
+type importRecord struct {
+	sourceID string
+	project  string
+	title    string
+	tags     []string
+	start    time.Time
+	end      time.Time
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var importer func(io.Reader, *time.Location) ([]importRecord, int, error)
+	switch importFormat {
+	case "toggl":
+		importer = importToggl
+	case "clockify":
+		importer = importClockify
+	case "tally-json":
+	case "":
+		return fmt.Errorf("--format is required (use 'toggl', 'clockify', or 'tally-json')")
+	default:
+		return fmt.Errorf("invalid format: %s (use 'toggl', 'clockify', or 'tally-json')", importFormat)
+	}
+	cmd.SilenceUsage = true
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	if importer == nil {
+		return importTallyJSON(f)
+	}
+
+	records, skipped, err := importer(f, db.Location)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	imported := 0
+	for _, rec := range records {
+		exists, err := db.HasEntryWithSourceID(rec.sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to check for imported entries: %w", err)
+		}
+		if exists {
+			skipped++
+			continue
+		}
+		if !importAllowOverlap {
+			overlapping, err := db.EntriesOverlapping(rec.start, rec.end)
+			if err != nil {
+				return fmt.Errorf("failed to check for overlapping entries: %w", err)
+			}
+			if len(overlapping) > 0 {
+				fmt.Fprintf(os.Stderr, "Skipping %s: overlaps %s\n", formatImportRecord(rec), formatOverlappingEntry(overlapping[0]))
+				skipped++
+				continue
+			}
+		}
+		if importDryRun {
+			fmt.Printf("Would import %s\n", formatImportRecord(rec))
+			imported++
+			continue
+		}
+		if err := createImportedEntry(rec); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	if importDryRun {
+		fmt.Printf("\nDry run: %d entries would be imported, %d skipped\n", imported, skipped)
+	} else {
+		fmt.Printf("Imported %d entries, %d skipped\n", imported, skipped)
+	}
+	return nil
+}
+
+// createImportedEntry stores rec as a completed entry, along with its source ID, using [createCompletedEntry].
+func createImportedEntry(rec importRecord) error {
+	_, err := createCompletedEntry(rec.project, rec.title, rec.tags, rec.start, rec.end, rec.sourceID)
+	return err
+}
+
+// importTallyJSON restores the entries in a JSON array written by `tally export`, creating their projects and tags by
+// name as needed.
+//
+// Entries keep their IDs, so entries that already exist are skipped and a backup can be imported again safely. Entries
+// overlapping an existing one are skipped unless --allow-overlap is set, as are running or paused entries whose timer
+// is already active. Invalid entries are reported on stderr and skipped. Each entry is written in its own
+// transaction by [db.RestoreEntry].
+func importTallyJSON(reader io.Reader) error {
+	var entries []model.Entry
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	imported, skipped := 0, 0
+	for i := range entries {
+		e := &entries[i]
+		if err := validateExportedEntry(e); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping entry %d: %v\n", i+1, err)
+			skipped++
+			continue
+		}
+
+		exists, err := db.HasEntry(e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check for imported entries: %w", err)
+		}
+		if exists {
+			skipped++
+			continue
+		}
+
+		if e.Status != model.StatusStopped {
+			active, err := db.GetRunningEntryForTimer(e.Timer)
+			if err != nil {
+				return fmt.Errorf("failed to check running entry: %w", err)
+			}
+			if active != nil {
+				fmt.Fprintf(os.Stderr, "Skipping %s: timer already active with %s\n",
+					formatOverlappingEntry(*e), formatOverlappingEntry(*active))
+				skipped++
+				continue
+			}
+		}
+
+		if !importAllowOverlap {
+			end := db.Now()
+			if e.EndTime != nil {
+				end = *e.EndTime
+			}
+			overlapping, err := db.EntriesOverlapping(e.StartTime, end)
+			if err != nil {
+				return fmt.Errorf("failed to check for overlapping entries: %w", err)
+			}
+			if len(overlapping) > 0 {
+				fmt.Fprintf(os.Stderr, "Skipping %s: overlaps %s\n", formatOverlappingEntry(*e), formatOverlappingEntry(overlapping[0]))
+				skipped++
+				continue
+			}
+		}
+
+		if importDryRun {
+			fmt.Printf("Would import %s\n", formatOverlappingEntry(*e))
+			imported++
+			continue
+		}
+		if err := restoreExportedEntry(e); err != nil {
+			return fmt.Errorf("failed to import entry %s: %w", e.ID, err)
+		}
+		imported++
+	}
+
+	if importDryRun {
+		fmt.Printf("\nDry run: %d entries would be imported, %d skipped\n", imported, skipped)
+	} else {
+		fmt.Printf("Imported %d entries, %d skipped\n", imported, skipped)
+	}
+	return nil
+}
+
+// validateExportedEntry checks that an entry read by [importTallyJSON] can be restored: it has an ID, a project name,
+// a known status, and, once stopped, an end time after its start.
+func validateExportedEntry(e *model.Entry) error {
+	if e.ID == "" {
+		return fmt.Errorf("no id")
+	}
+	if e.Project == nil || e.Project.Name == "" {
+		return fmt.Errorf("no project")
+	}
+	switch e.Status {
+	case model.StatusRunning, model.StatusPaused:
+		if e.EndTime != nil {
+			return fmt.Errorf("%s entry has an end time", e.Status)
+		}
+	case model.StatusStopped:
+		if e.EndTime == nil {
+			return fmt.Errorf("stopped entry has no end time")
+		}
+		if e.EndTime.Before(e.StartTime) {
+			return fmt.Errorf("end time is before start time")
+		}
+	default:
+		return fmt.Errorf("invalid status: %q", e.Status)
+	}
+	return nil
+}
+
+// restoreExportedEntry points e at the projects and tags of this database, creating them by name if needed, and
+// stores it with [db.RestoreEntry].
+func restoreExportedEntry(e *model.Entry) error {
+	project, err := db.GetOrCreateProject(e.Project.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get/create project: %w", err)
+	}
+	e.ProjectID = project.ID
+
+	for i, t := range e.Tags {
+		tag, err := db.GetOrCreateTag(t.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get/create tag '%s': %w", t.Name, err)
+		}
+		e.Tags[i].ID = tag.ID
+	}
+
+	return db.RestoreEntry(e)
+}
+
+// formatImportRecord describes rec on one line, e.g. `@work: standup +meeting [2024-01-15 09:00 - 09:15]`.
+func formatImportRecord(rec importRecord) string {
+	s := "@" + rec.project
+	if rec.title != "" {
+		s += ": " + rec.title
+	}
+	if len(rec.tags) > 0 {
+		s += " " + formatTags(rec.tags)
+	}
+	return fmt.Sprintf("%s [%s - %s]", s, rec.start.Format("2006-01-02 15:04"), rec.end.Format("2006-01-02 15:04"))
+}
+
+// formatOverlappingEntry describes an existing entry in the style of [formatImportRecord], prefixed with its ID.
+func formatOverlappingEntry(e model.Entry) string {
+	end := "now"
+	if e.EndTime != nil {
+		end = e.EndTime.Format("2006-01-02 15:04")
+	}
+	s := e.ID + " @" + e.Project.Name
+	if e.Title != "" {
+		s += ": " + e.Title
+	}
+	if len(e.Tags) > 0 {
+		s += " " + formatTagsFromModel(e.Tags)
+	}
+	return fmt.Sprintf("%s [%s - %s]", s, e.StartTime.Format("2006-01-02 15:04"), end)
+}
+
+// importToggl parses a Toggl Track "Detailed" CSV export.
+//
+// Description becomes the title, Project the project, and the comma-separated Tags the tags. The Start date/Start time
+// and End date/End time columns are combined into local timestamps. Rows without a project or with unparseable times
+// are reported on stderr and skipped.
+//
+// Returns the parsed records and the number of skipped rows, or an error if the CSV itself cannot be read or lacks a
+// required column.
+func importToggl(reader io.Reader, loc *time.Location) ([]importRecord, int, error) {
+	return importDetailedCSV(reader, "toggl", loc)
+}
+
+// importClockify parses a Clockify "Detailed report" CSV export.
+//
+// Clockify uses the same column names as Toggl, differing only in case, and its dates may be written as
+// MM/DD/YYYY with 12-hour times depending on the workspace settings; see [parseImportTime].
+//
+// Returns the parsed records and the number of skipped rows, or an error if the CSV itself cannot be read or lacks a
+// required column.
+func importClockify(reader io.Reader, loc *time.Location) ([]importRecord, int, error) {
+	return importDetailedCSV(reader, "clockify", loc)
+}
+
+// importDetailedCSV parses a CSV export with Project, Description, Tags, Start date, Start time, End date, and End
+// time columns, matched ignoring case. Rows that cannot be turned into an [importRecord] are reported on stderr and
+// skipped.
+//
+// The exports record wall-clock times without a zone, so they are read in loc.
+//
+// These exports carry no row IDs, so each record's source ID is source followed by a hash of the whole row; see
+// [csvRowID].
+func importDetailedCSV(reader io.Reader, source string, loc *time.Location) ([]importRecord, int, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns, err := csvColumns(header, "Project", "Description", "Tags", "Start date", "Start time", "End date", "End time")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var records []importRecord
+	skipped := 0
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i := columns[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		rec, err := newImportRecord(field("Project"), field("Description"), field("Tags"),
+			field("Start date")+" "+field("Start time"), field("End date")+" "+field("End time"), loc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping line %d: %v\n", line, err)
+			skipped++
+			continue
+		}
+		rec.sourceID = csvRowID(source, row)
+		records = append(records, rec)
+	}
+	return records, skipped, nil
+}
+
+// csvRowID returns a stable source ID for a CSV row without an ID column, like "toggl:3f2a9c0d1e4b5a6f".
+//
+// The ID is derived from every field, so an unchanged row maps to the same ID on every import, while a row edited in
+// the source tool is treated as new.
+func csvRowID(source string, row []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(row, "\x1f")))
+	return source + ":" + hex.EncodeToString(sum[:8])
+}
+
+// newImportRecord builds an [importRecord] from the text of an import row. tags is a comma-separated list, and start and
+// end are parsed in loc with [parseImportTime].
+//
+// Returns an error if the project is empty, a time cannot be parsed, or end is not after start.
+func newImportRecord(project, title, tags, start, end string, loc *time.Location) (importRecord, error) {
+	if project == "" {
+		return importRecord{}, fmt.Errorf("no project")
+	}
+	rec := importRecord{project: project, title: title}
+
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			rec.tags = append(rec.tags, tag)
+		}
+	}
+
+	var err error
+	if rec.start, err = parseImportTime(start, loc); err != nil {
+		return importRecord{}, err
+	}
+	if rec.end, err = parseImportTime(end, loc); err != nil {
+		return importRecord{}, err
+	}
+	if !rec.end.After(rec.start) {
+		return importRecord{}, fmt.Errorf("end time %s is not after start time %s", end, start)
+	}
+	return rec, nil
+}
+
+// importTimeLayouts lists the timestamp layouts accepted by [parseImportTime], in the order they are tried.
+var importTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"01/02/2006 03:04:05 PM",
+	"01/02/2006 03:04 PM",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+}
+
+// parseImportTime parses a wall-clock timestamp such as "2024-01-15 09:00:00" or "01/15/2024 09:00:00 AM" in loc.
+func parseImportTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q", s)
+}
+
+// csvColumns maps each of the required column names to its index in header, ignoring case and a leading byte order
+// mark.
+//
+// Returns an error naming the first required column that is missing.
+func csvColumns(header []string, required ...string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(required))
+	for _, name := range required {
+		i, ok := index[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+		columns[name] = i
+	}
+	return columns, nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+)
+
+func TestImportClockify(t *testing.T) {
+	openTestDB(t)
+	importFormat, importDryRun, importAllowOverlap = "clockify", false, false
+	t.Cleanup(func() { importFormat = "" })
+
+	if err := runImport(importCmd, []string{"testdata/clockify.csv"}); err != nil {
+		t.Fatalf("runImport() error = %v", err)
+	}
+
+	entries, err := db.ListEntries(db.ListEntriesOptions{Ascending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type imported struct {
+		project, title string
+		tags           []string
+		start          time.Time
+		duration       time.Duration
+	}
+	want := []imported{
+		{"Website", "Homepage redesign", []string{"client", "design"}, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), 90 * time.Minute},
+		{"Internal", "Weekly planning", []string{"meeting"}, time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC), 45 * time.Minute},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("imported %d entries, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		var tags []string
+		for _, tag := range e.Tags {
+			tags = append(tags, tag.Name)
+		}
+		slices.Sort(tags)
+		got := imported{e.Project.Name, e.Title, tags, e.StartTime, e.Duration()}
+		w := want[i]
+		if got.project != w.project || got.title != w.title || !slices.Equal(got.tags, w.tags) ||
+			!got.start.Equal(w.start) || got.duration != w.duration {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+		if e.SourceID == "" {
+			t.Errorf("entry %d has no source ID", i)
+		}
+	}
+
+	// Importing the same file again skips every row
+	if err := runImport(importCmd, []string{"testdata/clockify.csv"}); err != nil {
+		t.Fatalf("second runImport() error = %v", err)
+	}
+	count, err := db.CountEntries(db.ListEntriesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != len(want) {
+		t.Errorf("after re-import, %d entries, want %d", count, len(want))
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/config"
+	"github.com/thinktide/tally/internal/service"
+)
+
+// initCmd walks a new user through the common configuration settings and shell completion setup.
+//
+// Each setting is offered with its current value as the default, so running it again is safe and only changes what
+// the user types. Settings are written with [config.Set].
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up tally interactively",
+	Long: `Walk through the common configuration settings and optionally install
+shell completion. Press Enter to keep the current value of a setting.
+
+Changing the data location does not move existing entries; tally starts
+a new database there, or uses the one it finds.
+
+No cron entries are installed. Sleep detection needs none, as it runs
+before status, stop, pause, and report. Idle detection relies on
+'tally ping', which is best run from your own shell prompt or editor hook.
+
+Every setting can be changed later with 'tally config set'.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+// initSetting describes a configuration key offered by `tally init`. A nil valid list accepts any non-empty value.
+type initSetting struct {
+	key      string
+	question string
+	valid    []string
+}
+
+// initSettings lists the settings `tally init` asks about, in order.
+var initSettings = []initSetting{
+	{config.KeyOutputFormat, "Default output format (" + strings.Join(outputFormats, ", ") + ")", outputFormats},
+	{config.KeyReportDefaultPeriod, "Period to report when none is given (menu to choose each time, today, week, month, ...)", reportPeriodChoices()},
+	{config.KeyReportWeekStart, "First day of the week (monday, sunday)", []string{"monday", "sunday"}},
+	{config.KeyDataLocation, "Data directory", nil},
+	{config.KeyStartAutoStopPrevious, "Stop the running timer automatically when starting a new one (true, false)", []string{"true", "false"}},
+	{config.KeyDeleteDefaultYes, "Default to yes when confirming deletes (true, false)", []string{"true", "false"}},
+	{config.KeySleepDetection, "Record system sleep as pauses (true, false)", []string{"true", "false"}},
+}
+
+// reportPeriodChoices returns the accepted values of [config.KeyReportDefaultPeriod]: "menu" and every period.
+func reportPeriodChoices() []string {
+	choices := []string{"menu"}
+	for _, p := range service.AllPeriods {
+		choices = append(choices, string(p))
+	}
+	return choices
+}
+
+// initValue matches input against the accepted values of s, ignoring case, and returns the accepted spelling.
+func initValue(s initSetting, input string) (string, bool) {
+	if s.valid == nil {
+		return input, input != ""
+	}
+	for _, v := range s.valid {
+		if strings.EqualFold(v, input) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if !isInteractive() {
+		return fmt.Errorf("tally init must be run from a terminal")
+	}
+
+	fmt.Println("Setting up tally. Press Enter to keep the value in brackets.")
+	fmt.Println()
+
+	for _, s := range initSettings {
+		current, err := config.Get(s.key)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", s.key, err)
+		}
+
+		for {
+			input, err := ask(s.question, current)
+			if err != nil {
+				return err
+			}
+			value, ok := initValue(s, input)
+			if !ok {
+				if s.valid == nil {
+					fmt.Println("Please enter a value")
+				} else {
+					fmt.Printf("Please enter one of: %s\n", strings.Join(s.valid, ", "))
+				}
+				continue
+			}
+			if value != current {
+				if err := config.Set(s.key, value); err != nil {
+					return fmt.Errorf("failed to set %s: %w", s.key, err)
+				}
+				if s.key == config.KeyDataLocation {
+					fmt.Println("Existing entries stay where they are; tally uses the new location from the next command.")
+				}
+			}
+			break
+		}
+	}
+
+	fmt.Println()
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if _, ok := completionPaths[shell]; ok {
+		install, err := confirm(fmt.Sprintf("Install %s completion?", shell), true)
+		if err != nil {
+			return err
+		}
+		if install {
+			if err := installCompletion(shell); err != nil {
+				return err
+			}
+		}
+	} else {
+		fmt.Println("Run 'tally completion --help' to set up shell completion.")
+	}
+
+	fmt.Println()
+	fmt.Println("All set. Start tracking with: tally start @project")
+	return nil
+}
+
+// completionPaths maps supported shells to the completion script location, relative to the home directory.
+var completionPaths = map[string]string{
+	"bash": filepath.Join(".local", "share", "bash-completion", "completions", "tally"),
+	"zsh":  filepath.Join(".zsh", "completions", "_tally"),
+	"fish": filepath.Join(".config", "fish", "completions", "tally.fish"),
+}
+
+// installCompletion writes the completion script for shell to its location under the home directory.
+//
+// Returns an error if the shell is unsupported, or the script cannot be generated or written.
+func installCompletion(shell string) error {
+	rel, ok := completionPaths[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	path := filepath.Join(home, rel)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create completion file: %w", err)
+	}
+	defer f.Close()
+
+	switch shell {
+	case "bash":
+		err = rootCmd.GenBashCompletionV2(f, true)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(f)
+	case "fish":
+		err = rootCmd.GenFishCompletion(f, true)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate completion: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write completion file: %w", err)
+	}
+
+	fmt.Printf("Installed completion to %s\n", path)
+	if shell == "zsh" {
+		fmt.Printf("Add this to your ~/.zshrc if it is not already there:\n  fpath=(%s $fpath)\n  autoload -U compinit && compinit\n", filepath.Dir(path))
+	}
+	return nil
+}
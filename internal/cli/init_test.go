@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/thinktide/tally/internal/config"
+)
+
+func TestInitValue(t *testing.T) {
+	settings := make(map[string]initSetting)
+	for _, s := range initSettings {
+		settings[s.key] = s
+	}
+
+	tests := []struct {
+		key    string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{config.KeyOutputFormat, "Markdown", "markdown", true},
+		{config.KeyOutputFormat, "xml", "", false},
+		{config.KeyReportDefaultPeriod, "lastweek", "lastWeek", true},
+		{config.KeyReportDefaultPeriod, "menu", "menu", true},
+		{config.KeyDataLocation, "~/Dropbox/tally", "~/Dropbox/tally", true},
+		{config.KeyDataLocation, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.input, func(t *testing.T) {
+			s, ok := settings[tt.key]
+			if !ok {
+				t.Fatalf("tally init does not ask about %s", tt.key)
+			}
+			got, ok := initValue(s, tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("initValue(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInitChoicesAreValidConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	openTestDB(t)
+
+	for _, s := range initSettings {
+		for _, value := range s.valid {
+			var err error
+			captureOutput(t, func() { err = runConfigSet(configSetCmd, []string{s.key, value}) })
+			if err != nil {
+				t.Errorf("tally init offers %s = %s, which config set refuses: %v", s.key, value, err)
+			}
+		}
+	}
+}
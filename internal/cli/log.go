@@ -1,13 +1,18 @@
 package cli
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/color"
 	"github.com/thinktide/tally/internal/db"
 	"github.com/thinktide/tally/internal/model"
 )
@@ -17,10 +22,37 @@ import (
 // logFrom specifies the starting point or source of the logs.
 //
 // logTo specifies the endpoint or destination for the logs.
+//
+// logSince shows entries from a relative duration ago, such as "3d", instead of a --from date.
+//
+// logMaxEntries defines the number of entries above which confirmation is required before printing them.
+//
+// logSearch restricts the logs to entries whose title contains it, ignoring case.
+//
+// logStatus restricts the logs to entries with the given status: running, paused, or stopped.
+//
+// logAnyTag includes entries with any of the given tags instead of requiring all of them.
+//
+// logAll includes entries of archived projects, which are otherwise hidden unless the project is named.
+//
+// logSort orders the entries by one of [db.EntrySortFields] instead of by start time.
+//
+// logReverse lists the entries in ascending order, oldest or smallest first.
+//
+// logFormat defines the output format of [logCmd]. Accepts "table" (default), "json", or "csv".
 var (
-	logLimit int
-	logFrom  string
-	logTo    string
+	logLimit      int
+	logFrom       string
+	logTo         string
+	logSince      string
+	logMaxEntries int
+	logSearch     string
+	logStatus     string
+	logAnyTag     bool
+	logAll        bool
+	logSort       string
+	logReverse    bool
+	logFormat     string
 )
 
 // logCmd represents a CLI command to display time entries, optionally filtered by project, tags, or date ranges.
@@ -38,7 +70,17 @@ Examples:
   tally log --limit 20         # Last 20 entries
   tally log @work              # Entries for 'work' project
   tally log +backend           # Entries with 'backend' tag
-  tally log @work +backend     # Entries for 'work' with 'backend' tag`,
+  tally log @work +backend     # Entries for 'work' with 'backend' tag
+  tally log +backend +urgent   # Entries with both tags
+  tally log +bug +urgent --any-tag   # Entries with either tag
+  tally log --since 3d         # Entries from the last 3 days
+  tally log --search "bug fix" # Entries whose title contains "bug fix"
+  tally log --status paused    # Paused entries only
+  tally log --all              # Include archived projects
+  tally log --since 1d --reverse     # Today's work, oldest first
+  tally log --sort duration          # Longest entries first
+  tally log @work --format json | jq '.[].title'   # Full entries, including pauses
+  tally log --format csv       # Same columns as the report CSV`,
 	RunE: runLog,
 }
 
@@ -48,10 +90,28 @@ Examples:
 //   - "limit" (-n): An integer flag specifying the number of log entries to show (default: 10).
 //   - "from": A string flag specifying the start date in YYYY-MM-DD format.
 //   - "to": A string flag specifying the end date in YYYY-MM-DD format.
+//   - "since": A string flag specifying a relative start such as 12h, 3d, or 2w, instead of "from".
+//   - "max-entries": An integer flag specifying the number of entries above which confirmation is required.
+//   - "search": A string flag matching entries whose title contains the text, ignoring case.
+//   - "status": A string flag selecting entries that are running, paused, or stopped.
+//   - "any-tag": A boolean flag matching entries with any of the given tags instead of all of them.
+//   - "all": A boolean flag including entries of archived projects.
+//   - "sort": A string flag ordering entries by start, end, duration, project, or title.
+//   - "reverse": A boolean flag listing entries in ascending order.
+//   - "format": A string flag selecting table, json, or csv output.
 func init() {
 	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 10, "Number of entries to show")
 	logCmd.Flags().StringVar(&logFrom, "from", "", "Start date (YYYY-MM-DD)")
 	logCmd.Flags().StringVar(&logTo, "to", "", "End date (YYYY-MM-DD)")
+	logCmd.Flags().StringVar(&logSince, "since", "", "Show entries from this long ago, e.g. 12h, 3d, 2w")
+	logCmd.Flags().IntVar(&logMaxEntries, "max-entries", defaultMaxEntries, "Ask for confirmation above this many entries (0 to disable)")
+	logCmd.Flags().StringVar(&logSearch, "search", "", "Only entries whose title contains this text (case-insensitive)")
+	logCmd.Flags().StringVar(&logStatus, "status", "", "Only entries with this status: running, paused, stopped")
+	logCmd.Flags().BoolVar(&logAnyTag, "any-tag", false, "Match entries with any of the given tags instead of all")
+	logCmd.Flags().BoolVar(&logAll, "all", false, "Include entries of archived projects")
+	logCmd.Flags().StringVar(&logSort, "sort", "start", "Order by: "+strings.Join(db.EntrySortFields, ", "))
+	logCmd.Flags().BoolVarP(&logReverse, "reverse", "r", false, "List in ascending order, oldest or smallest first")
+	logCmd.Flags().StringVar(&logFormat, "format", "table", "Output format: table, json, csv")
 }
 
 // runLog executes the logic to retrieve and display time entries based on given filters.
@@ -72,8 +132,19 @@ func init() {
 //   - An error if database operations fail or if invalid date formats are detected.
 //   - Otherwise, a list of matching entries is printed to the console, and `nil` is returned.
 func runLog(cmd *cobra.Command, args []string) error {
+	if logFormat != "table" && logFormat != "json" && logFormat != "csv" {
+		return fmt.Errorf("invalid format: %s (use 'table', 'json', or 'csv')", logFormat)
+	}
+	if !slices.Contains(db.EntrySortFields, logSort) {
+		return fmt.Errorf("invalid sort field: %s (use %s)", logSort, strings.Join(db.EntrySortFields, ", "))
+	}
+
 	opts := db.ListEntriesOptions{
-		Limit: logLimit,
+		Limit:           logLimit,
+		AnyTag:          logAnyTag,
+		ExcludeArchived: !logAll,
+		SortBy:          logSort,
+		Ascending:       logReverse,
 	}
 
 	// Parse filters from args
@@ -85,10 +156,17 @@ func runLog(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to get project: %w", err)
 			}
 			if project == nil {
+				if logFormat != "table" {
+					return printLogEntries(nil)
+				}
 				fmt.Printf("No entries found for project @%s\n", projectName)
-				return nil
+				if project, err = suggestProject(os.Stdout, projectName); err != nil || project == nil {
+					return err
+				}
 			}
 			opts.ProjectID = &project.ID
+			// Naming a project shows its entries even if it is archived
+			opts.ExcludeArchived = false
 		} else if strings.HasPrefix(arg, "+") {
 			tagName := strings.TrimPrefix(arg, "+")
 			tag, err := db.GetTagByName(tagName)
@@ -96,46 +174,91 @@ func runLog(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to get tag: %w", err)
 			}
 			if tag == nil {
+				if logFormat != "table" {
+					return printLogEntries(nil)
+				}
 				fmt.Printf("No entries found with tag +%s\n", tagName)
-				return nil
+				if tag, err = suggestTag(os.Stdout, tagName); err != nil || tag == nil {
+					return err
+				}
 			}
 			opts.TagIDs = append(opts.TagIDs, tag.ID)
 		}
 	}
 
 	// Parse date filters
-	if logFrom != "" {
-		t, err := time.Parse("2006-01-02", logFrom)
-		if err != nil {
-			return fmt.Errorf("invalid --from date (use YYYY-MM-DD): %w", err)
-		}
-		opts.From = &t
+	from, err := parseRangeStart(logFrom, logSince)
+	if err != nil {
+		return err
 	}
+	opts.From = from
 	if logTo != "" {
-		t, err := time.Parse("2006-01-02", logTo)
+		t, err := time.ParseInLocation("2006-01-02", logTo, db.Location)
 		if err != nil {
 			return fmt.Errorf("invalid --to date (use YYYY-MM-DD): %w", err)
 		}
 		// Add a day to include the entire 'to' date
-		t = t.Add(24 * time.Hour)
+		t = t.AddDate(0, 0, 1)
 		opts.To = &t
 	}
 
+	if logSearch != "" {
+		opts.TitleSearch = &logSearch
+	}
+	if logStatus != "" {
+		status := model.EntryStatus(logStatus)
+		switch status {
+		case model.StatusRunning, model.StatusPaused, model.StatusStopped:
+			opts.Status = &status
+		default:
+			return fmt.Errorf("invalid status: %s (use 'running', 'paused', or 'stopped')", logStatus)
+		}
+	}
+
+	proceed, err := checkMaxEntries(opts, logMaxEntries)
+	if err != nil || !proceed {
+		return err
+	}
+
 	entries, err := db.ListEntries(opts)
 	if err != nil {
 		return fmt.Errorf("failed to list entries: %w", err)
 	}
 
+	return printLogEntries(entries)
+}
+
+// printLogEntries prints entries in the format selected by --format: a table, a JSON array of full entries including
+// their pauses, or CSV with the same columns as the report CSV.
+func printLogEntries(entries []model.Entry) error {
+	switch logFormat {
+	case "json":
+		if entries == nil {
+			entries = []model.Entry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		now := db.Now()
+		writer.Write(entryCSVHeader)
+		for _, e := range entries {
+			writer.Write(entryCSVRow(e, e.Duration(), now))
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
 	if len(entries) == 0 {
 		fmt.Println("No entries found")
 		return nil
 	}
-
-	printEntriesTable(entries)
+	printEntriesTable(os.Stdout, entries)
 	return nil
 }
 
-// printEntriesTable formats and prints a table of entries to the console.
+// printEntriesTable formats and writes a table of entries to w, usually [os.Stdout].
 //
 // It uses [tablewriter.Writer] to create a well-structured table displaying key details of each [model.Entry].
 // The columns include "ID", "Project", "Title", "Duration", "Tags", and "Date". The function adjusts formatting
@@ -145,13 +268,16 @@ func runLog(cmd *cobra.Command, args []string) error {
 // entries is a slice of [model.Entry] objects, each representing a time-tracking entry with relevant metadata.
 // The function reads specific attributes such as ID, project name, title, duration, tags, and start time.
 //
-// Prints the resulting table to standard output with additional symbols included:
+// Writes the resulting table to w with additional symbols included:
 //   - "*" appended to the duration for running entries.
 //   - "~" appended to the duration for paused entries.
 //
+// When colors are on (see [color.Setup]), running and paused durations are green and yellow, and each project has its
+// own color.
+//
 // This function ensures alignment, removes unnecessary table borders, and disables text wrapping for readability.
-func printEntriesTable(entries []model.Entry) {
-	table := tablewriter.NewWriter(os.Stdout)
+func printEntriesTable(w io.Writer, entries []model.Entry) {
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"ID", "Project", "Title", "Duration", "Tags", "Date"})
 	table.SetBorder(false)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -168,9 +294,9 @@ func printEntriesTable(entries []model.Entry) {
 		duration := e.Duration()
 		durationStr := formatDurationShort(duration)
 		if e.Status == model.StatusRunning {
-			durationStr += "*"
+			durationStr = color.Green(durationStr + "*")
 		} else if e.Status == model.StatusPaused {
-			durationStr += "~"
+			durationStr = color.Yellow(durationStr + "~")
 		}
 
 		tags := make([]string, len(e.Tags))
@@ -184,8 +310,8 @@ func printEntriesTable(entries []model.Entry) {
 		}
 
 		table.Append([]string{
-			e.ID,
-			"@" + e.Project.Name,
+			color.Dim(shortID(e.ID)),
+			color.Project(e.Project.Name),
 			title,
 			durationStr,
 			strings.Join(tags, ", "),
@@ -194,5 +320,17 @@ func printEntriesTable(entries []model.Entry) {
 	}
 
 	table.Render()
-	fmt.Println("\n* = running, ~ = paused")
+	fmt.Fprintln(w, "\n* = running, ~ = paused")
+}
+
+// shortIDLength is the number of trailing ID characters shown by [shortID].
+const shortIDLength = 6
+
+// shortID returns the last [shortIDLength] characters of an entry ID for display in tables. ULIDs begin with a
+// timestamp, so their random tail tells entries apart; [db.ResolveEntryID] accepts it in place of the full ID.
+func shortID(id string) string {
+	if len(id) <= shortIDLength {
+		return id
+	}
+	return id[len(id)-shortIDLength:]
 }
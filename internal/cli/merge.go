@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// mergeForce allows [mergeCmd] to merge entries of different projects.
+var mergeForce bool
+
+// mergeCmd combines two consecutive entries into one, the inverse of [splitCmd].
+var mergeCmd = &cobra.Command{
+	Use:     "merge <id> <id>",
+	Aliases: []string{"merge-entries"},
+	Short:   "Merge two consecutive entries into one",
+	Long: `Merge two consecutive entries into one, such as a task stopped and
+restarted by mistake.
+
+The merged entry runs from the start of the earlier entry to the end of the
+later one, with a "Gap" pause for the time between them. It keeps the ID,
+project, title, note, and billing fields of the first entry given, and gains
+the tags and pauses of the second, which is deleted. The later entry may
+still be running.
+
+The entries must not overlap or have another entry between them, and must
+belong to the same project unless --force is given.
+
+IDs may be shortened to any unique prefix.
+
+Examples:
+  tally merge 01JQXYZ123 01JQXYZ456
+  tally merge 01JQ 01JR --force   # Keep the first entry's project`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMerge,
+}
+
+func init() {
+	mergeCmd.Flags().BoolVarP(&mergeForce, "force", "f", false, "Merge entries of different projects, keeping the first one's")
+}
+
+// runMerge merges the entry in args[1] into the entry in args[0] with [db.MergeEntries] and prints the result.
+//
+// Returns an error if either ID cannot be resolved, the projects differ without --force, or the merge fails.
+func runMerge(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	firstID, err := db.ResolveEntryID(args[0])
+	if err != nil {
+		return err
+	}
+	secondID, err := db.ResolveEntryID(args[1])
+	if err != nil {
+		return err
+	}
+
+	first, err := db.GetEntryByID(firstID)
+	if err != nil {
+		return fmt.Errorf("failed to get entry: %w", err)
+	}
+	second, err := db.GetEntryByID(secondID)
+	if err != nil {
+		return fmt.Errorf("failed to get entry: %w", err)
+	}
+	if first.ProjectID != second.ProjectID && !mergeForce {
+		return fmt.Errorf("entries belong to different projects (@%s and @%s); use --force to keep @%s",
+			first.Project.Name, second.Project.Name, first.Project.Name)
+	}
+
+	merged, err := db.MergeEntries(firstID, secondID)
+	if err != nil {
+		return fmt.Errorf("failed to merge entries: %w", err)
+	}
+
+	fmt.Printf("Merged %s into %s\n\n", shortID(secondID), shortID(firstID))
+	printEntriesTable(os.Stdout, []model.Entry{*merged})
+	return nil
+}
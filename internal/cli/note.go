@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// noteCmd sets the note of an entry without opening the editor.
+//
+// The note is free-form text for detail that doesn't fit the one-line title. Words after the ID are joined with
+// spaces, so quoting is optional; an empty note clears it.
+var noteCmd = &cobra.Command{
+	Use:   "note <id> <text>",
+	Short: "Set the note of an entry",
+	Long: `Set a longer note on an entry. The ID may be shortened to any unique prefix.
+
+Examples:
+  tally note 01JQXYZ123 "Root cause was a stale cache; see PR 482"
+  tally note 01JQ ""      # Clear the note`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runNote,
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	entryID, err := db.ResolveEntryID(args[0])
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	note := strings.TrimSpace(strings.Join(args[1:], " "))
+	if err := db.SetEntryNote(entryID, note); err != nil {
+		return fmt.Errorf("failed to set note: %w", err)
+	}
+
+	if note == "" {
+		fmt.Printf("Cleared note on %s\n", entryID)
+	} else {
+		fmt.Printf("Set note on %s\n", entryID)
+	}
+	return nil
+}
@@ -13,11 +13,18 @@ import (
 	"github.com/thinktide/tally/internal/model"
 )
 
+// pauseFrom and pauseTo bound a historical pause recorded with --from and --to.
+//
+// pauseReason is recorded as the reason of the pause, such as "lunch" or "meeting".
 var (
-	pauseFrom string
-	pauseTo   string
+	pauseFrom   string
+	pauseTo     string
+	pauseReason string
 )
 
+// defaultPauseReason is the pause reason recorded by `tally pause` when --reason is not given.
+const defaultPauseReason = "Manual"
+
 // pauseCmd represents a command to pause the currently running timer.
 //
 // This command updates the status of a running timer to "paused" and records the pause event in the database.
@@ -32,7 +39,11 @@ Examples:
   tally pause                    # Pause now
   tally pause -f 09:00           # Record pause from 9am to now
   tally pause -f 09:00 -t 10:30  # Record pause from 9am to 10:30am
-  tally pause 01JQXYZ123         # Add a pause to a past entry by ID`,
+  tally pause 01JQXYZ123         # Add a pause to a past entry by ID
+  tally pause -r lunch           # Pause now with reason "lunch"
+  tally pause -f 14:00 -t 15:00 -r meeting   # Record a meeting
+
+If several timers are active, they are listed and an entry ID must be given to choose one.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPause,
 }
@@ -40,53 +51,72 @@ Examples:
 func init() {
 	pauseCmd.Flags().StringVarP(&pauseFrom, "from", "f", "", "Pause start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
 	pauseCmd.Flags().StringVarP(&pauseTo, "to", "t", "", "Pause end time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	pauseCmd.Flags().StringVarP(&pauseReason, "reason", "r", defaultPauseReason, "Reason for the pause, e.g. lunch, meeting, interrupt")
 }
 
-// parseTimeInput parses a time string in various formats.
+// parseTimeInput parses a time string in various formats, in the configured time zone.
 // Supports: "HH:MM", "HH:MM:SS", "YYYY-MM-DD HH:MM:SS"
 func parseTimeInput(input string) (time.Time, error) {
-	now := time.Now()
+	return parseTimeInputAt(input, db.Now())
+}
+
+// parseTimeInputAt parses a time string like [parseTimeInput], in the time zone of now, with times of day falling on
+// the day of now.
+func parseTimeInputAt(input string, now time.Time) (time.Time, error) {
+	loc := now.Location()
 
 	// Strip surrounding quotes (single or double)
 	input = strings.Trim(input, "\"'")
 
 	// Try full datetime with seconds
-	if t, err := time.ParseInLocation("2006-01-02 15:04:05", input, time.Local); err == nil {
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", input, loc); err == nil {
 		return t, nil
 	}
 
 	// Try full datetime without seconds
-	if t, err := time.ParseInLocation("2006-01-02 15:04", input, time.Local); err == nil {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", input, loc); err == nil {
 		return t, nil
 	}
 
 	// Try HH:MM:SS (today)
-	if t, err := time.ParseInLocation("15:04:05", input, time.Local); err == nil {
-		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+	if t, err := time.ParseInLocation("15:04:05", input, loc); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
 	}
 
 	// Try HH:MM (today)
-	if t, err := time.ParseInLocation("15:04", input, time.Local); err == nil {
-		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	if t, err := time.ParseInLocation("15:04", input, loc); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
 	}
 
 	return time.Time{}, fmt.Errorf("invalid time format: %s (use HH:MM, HH:MM:SS, or YYYY-MM-DD HH:MM:SS)", input)
 }
 
 func runPause(cmd *cobra.Command, args []string) error {
-	// If an entry ID is provided, add a pause to that specific entry
+	reason := strings.TrimSpace(pauseReason)
+	if reason == "" {
+		return fmt.Errorf("pause reason cannot be empty")
+	}
+
+	// If an entry ID is provided, add a pause to that specific entry. Active entries are paused as usual instead, which
+	// allows choosing between several active timers.
 	if len(args) == 1 {
 		cmd.SilenceUsage = true
-		return pauseByID(args[0])
+		entryID, err := db.ResolveEntryID(args[0])
+		if err != nil {
+			return err
+		}
+		entry, err := db.GetEntryByID(entryID)
+		if err != nil {
+			return fmt.Errorf("entry not found: %w", err)
+		}
+		if entry.Status == model.StatusStopped {
+			return pauseByID(entry.ID, reason)
+		}
 	}
 
-	entry, err := db.GetRunningEntry()
-	if err != nil {
-		return fmt.Errorf("failed to get running entry: %w", err)
-	}
-	if entry == nil {
-		fmt.Println("No timer running")
-		return nil
+	entry, err := getActiveEntry(args)
+	if err != nil || entry == nil {
+		return err
 	}
 
 	// Handle historical pause with --from flag
@@ -102,7 +132,7 @@ func runPause(cmd *cobra.Command, args []string) error {
 		}
 
 		// Default to now if --to not specified
-		toTime := time.Now()
+		toTime := db.Now()
 		if pauseTo != "" {
 			toTime, err = parseTimeInput(pauseTo)
 			if err != nil {
@@ -116,26 +146,34 @@ func runPause(cmd *cobra.Command, args []string) error {
 		}
 
 		// Create the historical pause (completed, doesn't change entry status)
-		_, err = db.CreatePause(entry.ID, fromTime, &toTime, "Manual")
+		_, err = db.CreatePause(entry.ID, fromTime, &toTime, reason)
 		if err != nil {
 			return fmt.Errorf("failed to create pause: %w", err)
 		}
 
-		fmt.Printf("Added pause: %s - %s (%s)\n",
+		fmt.Printf("Added pause: %s - %s (%s, %s)\n",
 			fromTime.Format("15:04:05"),
 			toTime.Format("15:04:05"),
-			formatDuration(toTime.Sub(fromTime)))
+			formatDuration(toTime.Sub(fromTime)),
+			reason)
 		return nil
 	}
 
 	// Regular pause (pause now)
+	return pauseNow(entry, reason)
+}
+
+// pauseNow pauses the active entry at the current time, recording reason on the pause.
+//
+// An entry that is already paused is left alone and its status is printed instead.
+func pauseNow(entry *model.Entry, reason string) error {
 	if entry.Status == model.StatusPaused {
 		fmt.Println("Timer is already paused")
-		printStatus(entry)
+		printStatus(os.Stdout, entry)
 		return nil
 	}
 
-	if err := db.PauseEntry(entry.ID, "Manual"); err != nil {
+	if err := db.PauseEntry(entry.ID, reason); err != nil {
 		return fmt.Errorf("failed to pause entry: %w", err)
 	}
 
@@ -143,12 +181,17 @@ func runPause(cmd *cobra.Command, args []string) error {
 	if entry.Title != "" {
 		fmt.Printf(": %s", entry.Title)
 	}
-	fmt.Printf(" [%s elapsed]\n", formatDuration(entry.Duration()))
+	fmt.Printf(" [%s elapsed]", formatDuration(entry.Duration()))
+	if reason != defaultPauseReason {
+		fmt.Printf(" (%s)", reason)
+	}
+	fmt.Println()
 
 	return nil
 }
 
-func pauseByID(entryID string) error {
+// pauseByID prompts for the times of a pause to add to the stopped entry with entryID, recording reason on it.
+func pauseByID(entryID, reason string) error {
 	entry, err := db.GetEntryByID(entryID)
 	if err != nil {
 		return fmt.Errorf("entry not found: %w", err)
@@ -249,7 +292,7 @@ func pauseByID(entryID string) error {
 	}
 
 	// Create the pause
-	_, err = db.CreatePause(entryID, fromTime, toTime, "Manual")
+	_, err = db.CreatePause(entryID, fromTime, toTime, reason)
 	if err != nil {
 		return fmt.Errorf("failed to create pause: %w", err)
 	}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeInputAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone America/New_York not available: %v", err)
+	}
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"09:30", time.Date(2024, 3, 10, 9, 30, 0, 0, loc)},
+		{"'09:30:15'", time.Date(2024, 3, 10, 9, 30, 15, 0, loc)},
+		{"2024-01-02 08:00", time.Date(2024, 1, 2, 8, 0, 0, 0, loc)},
+		{"2024-01-02 08:00:05", time.Date(2024, 1, 2, 8, 0, 5, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseTimeInputAt(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) || got.Location() != loc {
+				t.Errorf("parseTimeInputAt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseTimeInputAt("9.30", now); err == nil {
+		t.Error("parseTimeInputAt(9.30) succeeded, want an error")
+	}
+}
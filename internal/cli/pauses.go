@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// pausesFormat defines the output format of [pausesCmd]. Accepts "table" (default) or "json".
+var pausesFormat string
+
+// pausesCmd lists the pauses of an entry with their durations and reasons.
+var pausesCmd = &cobra.Command{
+	Use:   "pauses [id]",
+	Short: "List the pauses of an entry",
+	Long: `List the pauses of an entry, with their durations and reasons. Without
+an ID, the pauses of the active timer are listed.
+
+Examples:
+  tally pauses
+  tally pauses 01JQXYZ123
+  tally pauses 01JQ --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPauses,
+}
+
+func init() {
+	pausesCmd.Flags().StringVar(&pausesFormat, "format", "table", "Output format: table, json")
+}
+
+// runPauses prints the pauses of the entry given by args[0], which may be stopped, or of the active entry (see
+// [getActiveEntry]).
+//
+// Returns an error if the format is unknown, the ID cannot be resolved, or the entry cannot be loaded.
+func runPauses(cmd *cobra.Command, args []string) error {
+	if pausesFormat != "table" && pausesFormat != "json" {
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", pausesFormat)
+	}
+	cmd.SilenceUsage = true
+
+	var entry *model.Entry
+	if len(args) == 1 {
+		entryID, err := db.ResolveEntryID(args[0])
+		if err != nil {
+			return err
+		}
+		if entry, err = db.GetEntryByID(entryID); err != nil {
+			return fmt.Errorf("entry not found: %w", err)
+		}
+	} else {
+		var err error
+		if entry, err = getActiveEntry(nil); err != nil || entry == nil {
+			return err
+		}
+	}
+
+	if pausesFormat == "json" {
+		pauses := entry.Pauses
+		if pauses == nil {
+			pauses = []model.Pause{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(pauses)
+	}
+
+	fmt.Printf("Entry %s: @%s", shortID(entry.ID), entry.Project.Name)
+	if entry.Title != "" {
+		fmt.Printf(": %s", entry.Title)
+	}
+	fmt.Println()
+	if len(entry.Pauses) == 0 {
+		fmt.Println("No pauses")
+		return nil
+	}
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Paused", "Resumed", "Duration", "Reason"})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	var total time.Duration
+	for _, p := range entry.Pauses {
+		resumed := "open"
+		if p.ResumeTime != nil {
+			resumed = p.ResumeTime.Format("2006-01-02 15:04:05")
+		}
+		total += p.Duration()
+		table.Append([]string{
+			shortID(p.ID),
+			p.PauseTime.Format("2006-01-02 15:04:05"),
+			resumed,
+			formatDuration(p.Duration()),
+			p.Reason,
+		})
+	}
+	table.Render()
+
+	fmt.Printf("\nPaused: %s (%d pause(s))\n", formatDuration(total), len(entry.Pauses))
+	return nil
+}
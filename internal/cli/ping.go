@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// pingCmd records user activity for idle detection.
+//
+// It is meant to be called from a shell prompt or editor hook. If `idle.threshold` is set and a running timer has
+// gone longer than that without a ping, the idle time is recorded as a pause before the activity is updated.
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Record activity for idle detection",
+	Long: `Record that you are active, for idle detection.
+
+Call this from a shell prompt or editor hook. When idle.threshold is set
+(e.g. 'tally config set idle.threshold 15m') and a running timer has gone
+longer than that without a ping, the time since the last ping is recorded
+as an "Idle" pause. Commands that read or change the running timer, such as
+status, stop, and report, check for idle time the same way.
+
+Examples:
+  tally ping
+  PROMPT_COMMAND='tally ping >/dev/null 2>&1'   # bash: ping on every prompt`,
+	Args: cobra.NoArgs,
+	RunE: runPing,
+}
+
+// runPing records idle time on the running timers with [checkIdle], and then updates the last activity time. It
+// prints nothing unless an idle pause was recorded, which is reported on stderr.
+//
+// Returns an error if the activity update fails.
+func runPing(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	checkIdle()
+
+	if err := db.UpdateActivity(); err != nil {
+		return fmt.Errorf("failed to update activity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// projectsWithDates specifies whether [projectsCmd] shows first-seen and last-activity dates.
+//
+// projectsFormat defines the output format of [projectsCmd]. Accepts "table" (default) or "json".
+//
+// projectsAll specifies whether [projectsCmd] includes archived projects.
+var (
+	projectsWithDates bool
+	projectsFormat    string
+	projectsAll       bool
+)
+
+// projectsCmd lists all projects with the number of entries recorded for each.
+//
+// With --with-dates, it also shows when each project was first seen and when it was last worked on, which gives a
+// timeline of engagements.
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List projects",
+	Long: `List all projects with their entry counts. Archived projects are hidden unless --all is given.
+
+Examples:
+  tally projects                    # List projects
+  tally projects --all              # Include archived projects
+  tally projects --with-dates       # Include first-seen and last-activity dates
+  tally projects --format json      # Output as JSON
+  tally projects merge @old @new    # Move all @old entries to @new`,
+	Args: cobra.NoArgs,
+	RunE: runProjects,
+}
+
+// projectsMergeCmd moves all entries of one project into another and deletes the emptied project.
+var projectsMergeCmd = &cobra.Command{
+	Use:   "merge <@source> <@dest>",
+	Short: "Merge one project into another",
+	Long: `Move every entry of the source project to the destination project, then
+delete the source project.
+
+Example:
+  tally projects merge @work-client-a @work`,
+	Args: cobra.ExactArgs(2),
+	RunE: runProjectsMerge,
+}
+
+// init configures flags and subcommands for the [projectsCmd] command.
+func init() {
+	projectsCmd.Flags().BoolVar(&projectsWithDates, "with-dates", false, "Show first-seen and last-activity dates")
+	projectsCmd.Flags().StringVar(&projectsFormat, "format", "table", "Output format: table, json")
+	projectsCmd.Flags().BoolVar(&projectsAll, "all", false, "Include archived projects")
+	projectsCmd.AddCommand(projectsMergeCmd)
+}
+
+// runProjects lists projects using [db.ListProjectsWithActivity] in the requested format.
+//
+// The JSON output always includes the dates, regardless of --with-dates.
+//
+// Returns an error if the projects cannot be loaded or the format is unknown.
+func runProjects(cmd *cobra.Command, args []string) error {
+	projects, err := db.ListProjectsWithActivity(projectsAll)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	switch projectsFormat {
+	case "json":
+		if projects == nil {
+			projects = []db.ProjectActivity{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(projects)
+	case "table":
+	default:
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", projectsFormat)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No projects found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Project", "Entries"}
+	if projectsWithDates {
+		header = append(header, "First Seen", "Last Activity")
+	}
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	for _, p := range projects {
+		name := "@" + p.Name
+		if p.Archived {
+			name += " (archived)"
+		}
+		row := []string{name, strconv.Itoa(p.Entries)}
+		if projectsWithDates {
+			lastActivity := "-"
+			if p.LastActivity != nil {
+				lastActivity = p.LastActivity.Format("2006-01-02")
+			}
+			row = append(row, p.CreatedAt.Format("2006-01-02"), lastActivity)
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	return nil
+}
+
+// runProjectsMerge merges the source project into the destination project with [db.MergeProjects].
+//
+// Returns an error if either name is not a project, either project does not exist, they are the same project, or
+// the merge fails.
+func runProjectsMerge(cmd *cobra.Command, args []string) error {
+	if !strings.HasPrefix(args[0], "@") || !strings.HasPrefix(args[1], "@") {
+		return fmt.Errorf("both names must be projects (@name)")
+	}
+	sourceName, destName := strings.TrimPrefix(args[0], "@"), strings.TrimPrefix(args[1], "@")
+	cmd.SilenceUsage = true
+
+	source, err := db.GetProjectByName(sourceName)
+	if err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if source == nil {
+		return fmt.Errorf("project @%s not found", sourceName)
+	}
+	dest, err := db.GetProjectByName(destName)
+	if err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if dest == nil {
+		return fmt.Errorf("project @%s not found", destName)
+	}
+
+	moved, err := db.MergeProjects(source.ID, dest.ID)
+	if err != nil {
+		return fmt.Errorf("failed to merge projects: %w", err)
+	}
+
+	fmt.Printf("Merged @%s into @%s (%d entries moved)\n", sourceName, destName, moved)
+	return nil
+}
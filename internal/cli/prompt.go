@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/thinktide/tally/internal/db"
+)
+
+// stdin is shared by the prompt helpers so that answers piped in together are not lost to a previous prompt's buffer.
+var stdin = bufio.NewReader(os.Stdin)
+
+// isInteractive reports whether stdin is attached to a terminal, meaning the user can answer prompts.
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm prints question followed by a "[y/N]" or "[Y/n]" hint and reads the answer from stdin.
+//
+// Answers of "y"/"yes" and "n"/"no" are accepted case-insensitively. An empty answer selects defaultYes; any other
+// answer counts as no.
+//
+// Returns the user's choice, or an error if reading stdin fails.
+func confirm(question string, defaultYes bool) (bool, error) {
+	return confirmOn(os.Stdout, question, defaultYes)
+}
+
+// confirmOn is like [confirm] but prints the question to w, so commands whose stdout is data can prompt on stderr.
+func confirmOn(w io.Writer, question string, defaultYes bool) (bool, error) {
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	fmt.Fprintf(w, "%s %s: ", question, hint)
+
+	input, err := stdin.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	input = strings.TrimSpace(strings.ToLower(input))
+	if input == "" {
+		return defaultYes, nil
+	}
+	return input == "y" || input == "yes", nil
+}
+
+// confirmTyped asks the user to type phrase exactly to confirm a dangerous operation.
+//
+// Returns true only if the typed text matches phrase after trimming surrounding whitespace. Returns an error if
+// reading stdin fails.
+func confirmTyped(question, phrase string) (bool, error) {
+	fmt.Printf("%s Type '%s' to confirm: ", question, phrase)
+
+	input, err := stdin.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(input) == phrase, nil
+}
+
+// ask prints question with def as the suggested answer and reads a line from stdin.
+//
+// Returns the trimmed answer, def if the answer is empty, or an error if reading stdin fails.
+func ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	input, err := stdin.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return def, nil
+	}
+	return input, nil
+}
+
+// checkMaxEntries guards against loading an unexpectedly large number of entries.
+//
+// It counts the entries matching opts using [db.CountEntries] and, if the count exceeds max, asks the user whether to
+// continue. In non-interactive mode it returns an error instead. A max of 0 disables the check.
+//
+// Returns true if the caller should proceed, false if the user declined, or an error if counting fails or the limit
+// is exceeded without a terminal to confirm on.
+func checkMaxEntries(opts db.ListEntriesOptions, max int) (bool, error) {
+	if max <= 0 || (opts.Limit > 0 && opts.Limit <= max) {
+		return true, nil
+	}
+
+	count, err := db.CountEntries(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to count entries: %w", err)
+	}
+	if opts.Limit > 0 && count > opts.Limit {
+		count = opts.Limit
+	}
+	if count <= max {
+		return true, nil
+	}
+
+	if !isInteractive() {
+		return false, fmt.Errorf("%d entries match, which exceeds --max-entries %d", count, max)
+	}
+
+	ok, err := confirm(fmt.Sprintf("%d entries match, which exceeds --max-entries %d. Continue?", count, max), false)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Println("Cancelled")
+	}
+	return ok, nil
+}
+
+// selectEntryCount is the number of recent entries [selectEntry] offers.
+const selectEntryCount = 10
+
+// selectEntry lists the most recent entries, numbered from the newest, and reads the user's choice from stdin. An
+// empty answer picks the most recent entry. verb completes the prompt, as in "Select an entry to edit".
+//
+// Without a terminal to ask on, or when last is true, the most recent entry is picked without asking.
+//
+// Returns the ID of the chosen entry, an empty string if there are no entries, or an error if listing entries, reading
+// stdin, or the selection fails.
+func selectEntry(verb string, last bool) (string, error) {
+	limit := selectEntryCount
+	if last || !isInteractive() {
+		limit = 1
+	}
+	entries, err := db.ListEntries(db.ListEntriesOptions{Limit: limit})
+	if err != nil {
+		return "", fmt.Errorf("failed to list entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	if limit == 1 {
+		return entries[0].ID, nil
+	}
+
+	fmt.Printf("Select an entry to %s:\n", verb)
+	fmt.Println()
+	for i, e := range entries {
+		line := fmt.Sprintf("  %2d. %s  %s  @%s", i+1, shortID(e.ID), e.StartTime.Format("2006-01-02 15:04"), e.Project.Name)
+		if e.Title != "" {
+			line += "  " + e.Title
+		}
+		fmt.Printf("%s  (%s)\n", line, formatDuration(e.Duration()))
+	}
+	fmt.Println()
+	fmt.Printf("Enter number (1-%d) [1]: ", len(entries))
+
+	input, err := stdin.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return entries[0].ID, nil
+	}
+
+	var choice int
+	if _, err := fmt.Sscanf(input, "%d", &choice); err != nil {
+		return "", fmt.Errorf("invalid selection")
+	}
+	if choice < 1 || choice > len(entries) {
+		return "", fmt.Errorf("invalid selection: choose 1-%d", len(entries))
+	}
+	return entries[choice-1].ID, nil
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// renameCmd renames a project or a tag in place.
+//
+// Both arguments must use the same sigil: `@` for projects or `+` for tags. Entries pick up the new name
+// automatically because they reference projects and tags by ID.
+var renameCmd = &cobra.Command{
+	Use:   "rename <@old|+old> <@new|+new>",
+	Short: "Rename a project or tag",
+	Long: `Rename a project or tag. All existing entries show the new name.
+
+Examples:
+  tally rename @acme @acme-corp   # Rename a project
+  tally rename +bug +bugfix       # Rename a tag`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRename,
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	oldArg, newArg := args[0], args[1]
+
+	switch {
+	case strings.HasPrefix(oldArg, "@") && strings.HasPrefix(newArg, "@"):
+		oldName, newName := strings.TrimPrefix(oldArg, "@"), strings.TrimPrefix(newArg, "@")
+		if newName == "" {
+			return fmt.Errorf("new project name cannot be empty")
+		}
+		cmd.SilenceUsage = true
+
+		project, err := db.GetProjectByName(oldName)
+		if err != nil {
+			return fmt.Errorf("failed to look up project: %w", err)
+		}
+		if project == nil {
+			fmt.Printf("No project named @%s\n", oldName)
+			return nil
+		}
+		if err := db.RenameProject(project.ID, newName); err != nil {
+			return fmt.Errorf("failed to rename project: %w", err)
+		}
+		fmt.Printf("Renamed @%s to @%s\n", oldName, newName)
+
+	case strings.HasPrefix(oldArg, "+") && strings.HasPrefix(newArg, "+"):
+		oldName, newName := strings.TrimPrefix(oldArg, "+"), strings.TrimPrefix(newArg, "+")
+		if newName == "" {
+			return fmt.Errorf("new tag name cannot be empty")
+		}
+		cmd.SilenceUsage = true
+
+		tag, err := db.GetTagByName(oldName)
+		if err != nil {
+			return fmt.Errorf("failed to look up tag: %w", err)
+		}
+		if tag == nil {
+			fmt.Printf("No tag named +%s\n", oldName)
+			return nil
+		}
+		if err := db.RenameTag(tag.ID, newName); err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+		fmt.Printf("Renamed +%s to +%s\n", oldName, newName)
+
+	default:
+		return fmt.Errorf("both names must be projects (@name) or both must be tags (+name)")
+	}
+
+	return nil
+}
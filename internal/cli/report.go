@@ -5,11 +5,20 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/color"
 	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
 	"github.com/thinktide/tally/internal/model"
@@ -22,6 +31,67 @@ import (
 // If not explicitly set, it may fall back to a default value from the configuration.
 var reportFormat string
 
+// reportGroupBy selects the report's breakdown: a single headline grouping such as "week", or an additional breakdown
+// such as "tagset".
+//
+// reportFillZeroDays ensures the per-day breakdown contains every day of the period, including days without work.
+//
+// reportMaxEntries defines the number of entries above which confirmation is required before generating the report.
+//
+// reportEntriesAsEvents replaces the report with a chronological timeline of start, pause, resume, and stop events.
+//
+// reportAnyTag includes entries with any of the given tags instead of requiring all of them.
+//
+// reportBillableOnly restricts the report to billable entries.
+//
+// reportIncludeBilled includes entries already marked billed with 'tally bill'.
+//
+// reportIncludeRunning includes running and paused entries, measured up to now. It defaults to true.
+//
+// reportSplitMidnight splits entries that span midnight across the days they cover in the per-day and per-week totals.
+//
+// reportHeatmap replaces the report with the minutes worked on every day of the period, as JSON or a month-by-month
+// grid.
+//
+// reportTop limits the per-project and per-tag totals to this many rows, combining the rest.
+//
+// reportDetailed adds a row for each pause and separate gross and net durations to CSV output.
+//
+// reportSince starts the report a relative duration ago, such as "3d" (see [parseRelativeDuration]).
+//
+// reportDelimiter separates CSV fields: ",", ";", "|", or "tab" (see [csvDelimiters]).
+//
+// reportOutput is the file the report is written to instead of stdout.
+//
+// reportNoHeader writes only the entry rows of CSV output, leaving out header rows and the totals that follow.
+var (
+	reportGroupBy         string
+	reportFillZeroDays    bool
+	reportMaxEntries      int
+	reportEntriesAsEvents bool
+	reportFrom            string
+	reportTo              string
+	reportRound           string
+	reportAnyTag          bool
+	reportBillableOnly    bool
+	reportDelimiter       string
+	reportNoHeader        bool
+	reportOutput          string
+	reportDetailed        bool
+	reportTop             int
+	reportIncludeBilled   bool
+	reportIncludeRunning  bool
+	reportSplitMidnight   bool
+	reportHeatmap         bool
+	reportSince           string
+)
+
+// maxFilledDays is the longest period, in days, whose table output lists days without tracked time.
+const maxFilledDays = 31
+
+// defaultMaxEntries is the default value of the --max-entries flag on log and report.
+const defaultMaxEntries = 10000
+
 // reportCmd is a command that generates time reports for specified periods, projects, and tags.
 //
 // The command supports various time periods such as "today", "week", or "lastMonth".
@@ -30,7 +100,8 @@ var reportFormat string
 // When executed, reportCmd parses the input arguments and generates a time summary.
 // The summary can be output in different formats such as table, JSON, and CSV.
 //
-// If no period is provided, the command offers an interactive menu to select a time period.
+// If no period is provided, the period in [config.KeyReportDefaultPeriod] is used, or an interactive menu is offered
+// to select one.
 //
 // Errors may occur if an invalid period is provided, or if specified projects or tags are not found.
 // The generated report includes aggregated durations by project and tags, with detailed entry data.
@@ -42,23 +113,65 @@ var reportCmd = &cobra.Command{
 Periods:
   today, yesterday, week, lastWeek, month, lastMonth, year, lastYear
 
+Instead of a period, --from and --to select a custom date range. Both
+dates are inclusive; --to defaults to today. --since starts the range a
+number of hours, days, or weeks ago instead of --from.
+
+With no period, report.default_period is used, which shows an
+interactive menu unless it is set to a period.
+
 Examples:
-  tally report                    # Interactive menu
+  tally report                    # Interactive menu, or report.default_period
   tally report today              # Today's report
   tally report week @work         # This week's report for 'work' project
   tally report month +backend     # This month's report with 'backend' tag
-  tally report --format json      # Output as JSON`,
+  tally report week +bug +urgent --any-tag   # Entries with either tag
+  tally report --format json      # Output as JSON
+  tally report week --format markdown   # Tables for pasting into PRs and wikis
+  tally report --from 2024-01-01 --to 2024-01-15   # Custom date range
+  tally report --since 3d         # The last 3 days, up to now
+  tally report month --group-by week    # Only per-week totals
+  tally report week --group-by tagset   # Break down by exact tag combination
+  tally report month --group-by tag-prefix   # Group client:acme, client:globex under client
+  tally report week --group-by pause-reason  # Time spent paused per reason
+  tally report week --format json --fill-zero-days   # Gapless per-day series
+  tally report today --entries-as-events            # Narrative timeline of the day
+  tally report week --round 15m   # Bill in 15 minute increments
+  tally report week --billable-only   # Only billable entries
+  tally report week --format csv --delimiter tab --no-header | cut -f2
+  tally report week --format csv --detailed   # List pauses for auditing
+  tally report year --top 5       # Five biggest projects and tags, then (other)
+  tally report month --include-billed   # Include time already marked billed`,
 	RunE: runReport,
 }
 
 // init configures flags for the [reportCmd] command.
 //
 // The function binds the "format" flag to the variable reportFormat, allowing the use of different output formats:
-//   - format: Accepts "table", "json", or "csv" as values.
+//   - format: Accepts "table", "json", "csv", "markdown", or "html" as values.
 //
 // This setup enables users to customize the output format when generating reports.
 func init() {
-	reportCmd.Flags().StringVar(&reportFormat, "format", "", "Output format: table, json, csv")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "", "Output format: table, json, csv, markdown, html")
+	reportCmd.Flags().StringVar(&reportGroupBy, "group-by", "", "Breakdown: day, week, project, tag (only that one), or tagset, tag-prefix, pause-reason (in addition)")
+	reportCmd.Flags().IntVar(&reportMaxEntries, "max-entries", defaultMaxEntries, "Ask for confirmation above this many entries (0 to disable)")
+	reportCmd.Flags().BoolVar(&reportEntriesAsEvents, "entries-as-events", false, "Show a chronological timeline of events instead of totals")
+	reportCmd.Flags().BoolVar(&reportFillZeroDays, "fill-zero-days", false, "Include days without tracked time in the per-day breakdown")
+	reportCmd.Flags().BoolVar(&reportHeatmap, "heatmap", false, "Show minutes per day for every day of the period, as JSON or a month-by-month grid")
+	reportCmd.Flags().BoolVar(&reportSplitMidnight, "split-midnight", false, "Split entries spanning midnight across days in the per-day and per-week totals")
+	reportCmd.Flags().StringVar(&reportFrom, "from", "", "Start date of a custom range (YYYY-MM-DD)")
+	reportCmd.Flags().StringVar(&reportTo, "to", "", "End date of a custom range, inclusive (YYYY-MM-DD)")
+	reportCmd.Flags().StringVar(&reportSince, "since", "", "Start the range this long ago, e.g. 12h, 3d, 2w")
+	reportCmd.Flags().StringVar(&reportRound, "round", "", "Round each entry up to this increment, e.g. 15m (overrides report.rounding)")
+	reportCmd.Flags().BoolVar(&reportAnyTag, "any-tag", false, "Match entries with any of the given tags instead of all")
+	reportCmd.Flags().BoolVar(&reportBillableOnly, "billable-only", false, "Only include billable entries")
+	reportCmd.Flags().BoolVar(&reportIncludeBilled, "include-billed", false, "Include entries already marked billed")
+	reportCmd.Flags().BoolVar(&reportIncludeRunning, "include-running", true, "Include running and paused entries, measured up to now")
+	reportCmd.Flags().IntVar(&reportTop, "top", 0, "Only show the N projects and tags with the most time, combining the rest")
+	reportCmd.Flags().BoolVar(&reportDetailed, "detailed", false, "Include pauses and gross and net durations in CSV output")
+	reportCmd.Flags().StringVar(&reportDelimiter, "delimiter", ",", "CSV field delimiter: ',', ';', '|', or 'tab'")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to this file instead of stdout, creating its directory")
+	reportCmd.Flags().BoolVar(&reportNoHeader, "no-header", false, "Only write the entry rows of CSV output, without header rows or totals")
 }
 
 // runReport generates a report based on the provided options and arguments, and outputs it in the desired format.
@@ -82,8 +195,78 @@ func runReport(cmd *cobra.Command, args []string) error {
 		}
 		reportFormat = format
 	}
+	if reportDetailed && reportFormat != "csv" {
+		return fmt.Errorf("--detailed requires --format csv")
+	}
+	if reportHeatmap && reportFormat != "table" && reportFormat != "json" {
+		return fmt.Errorf("--heatmap requires --format table or json")
+	}
+	if reportHeatmap && reportEntriesAsEvents {
+		return fmt.Errorf("--heatmap cannot be combined with --entries-as-events")
+	}
+	if _, err := parseCSVDelimiter(reportDelimiter); err != nil {
+		return err
+	}
+
+	opts := service.ReportOptions{
+		GroupBy:       service.GroupBy(reportGroupBy),
+		FillZeroDays:  reportFillZeroDays,
+		AnyTag:        reportAnyTag,
+		Top:           reportTop,
+		IncludeBilled: reportIncludeBilled,
+		ExcludeActive: !reportIncludeRunning,
+		SplitMidnight: reportSplitMidnight,
+	}
+	if reportTop < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	if reportBillableOnly {
+		billable := true
+		opts.Billable = &billable
+	}
+	if !isValidGroupBy(opts.GroupBy) {
+		return fmt.Errorf("invalid --group-by: %s\nValid values: %v", reportGroupBy, service.AllGroupBys)
+	}
+	if opts.GroupBy == service.GroupByTagPrefix {
+		sep, err := config.Get(config.KeyReportTagSeparator)
+		if err != nil {
+			return err
+		}
+		opts.TagSeparator = sep
+	}
+
+	rounding := reportRound
+	if rounding == "" {
+		value, err := config.Get(config.KeyReportRounding)
+		if err != nil {
+			return err
+		}
+		rounding = value
+	}
+	increment, err := parseRounding(rounding)
+	if err != nil {
+		return fmt.Errorf("invalid rounding: %w", err)
+	}
+	opts.Rounding = increment
+
+	rates, err := config.Rates()
+	if err != nil {
+		return err
+	}
+	if len(rates) > 0 {
+		currency, err := config.Get(config.KeyReportCurrency)
+		if err != nil {
+			return err
+		}
+		opts.Rates = rates
+		opts.Currency = currency
+	}
+
+	cmd.SilenceUsage = true
 
-	opts := service.ReportOptions{}
+	// Unknown names are only offered a replacement when someone can answer. Otherwise, and in formats read by
+	// programs, they fail the report instead, with any suggestions in the error.
+	prompt := reportFormat == "table" && isInteractive()
 
 	// Parse arguments
 	for _, arg := range args {
@@ -94,8 +277,17 @@ func runReport(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to get project: %w", err)
 			}
 			if project == nil {
-				fmt.Printf("Project @%s not found\n", projectName)
-				return nil
+				if !prompt {
+					matches, err := db.SuggestProject(projectName)
+					if err != nil {
+						return fmt.Errorf("failed to suggest projects: %w", err)
+					}
+					return notFoundError("project", "@", projectName, matches)
+				}
+				fmt.Fprintf(os.Stderr, "Project @%s not found\n", projectName)
+				if project, err = suggestProject(os.Stderr, projectName); err != nil || project == nil {
+					return err
+				}
 			}
 			opts.ProjectID = &project.ID
 		} else if strings.HasPrefix(arg, "+") {
@@ -105,8 +297,17 @@ func runReport(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to get tag: %w", err)
 			}
 			if tag == nil {
-				fmt.Printf("Tag +%s not found\n", tagName)
-				return nil
+				if !prompt {
+					matches, err := db.SuggestTag(tagName)
+					if err != nil {
+						return fmt.Errorf("failed to suggest tags: %w", err)
+					}
+					return notFoundError("tag", "+", tagName, matches)
+				}
+				fmt.Fprintf(os.Stderr, "Tag +%s not found\n", tagName)
+				if tag, err = suggestTag(os.Stderr, tagName); err != nil || tag == nil {
+					return err
+				}
 			}
 			opts.TagIDs = append(opts.TagIDs, tag.ID)
 		} else {
@@ -115,25 +316,50 @@ func runReport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// If no period specified, show interactive menu
-	if opts.Period == "" {
-		period, err := selectPeriod()
+	if reportSince != "" && opts.Period != "" {
+		return fmt.Errorf("cannot combine period %s with --since", opts.Period)
+	}
+	if reportFrom != "" || reportTo != "" || reportSince != "" {
+		if opts.Period != "" {
+			return fmt.Errorf("cannot combine period %s with --from/--to", opts.Period)
+		}
+		if err := parseReportRange(&opts); err != nil {
+			return err
+		}
+	}
+
+	// If no period specified, use the configured default, or show the interactive menu
+	if opts.Period == "" && opts.From == nil {
+		period, err := config.Get(config.KeyReportDefaultPeriod)
 		if err != nil {
 			return err
 		}
-		opts.Period = period
+		opts.Period = service.Period(period)
+		if period == "menu" || period == "" {
+			if opts.Period, err = selectPeriod(); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Validate period
-	validPeriod := false
-	for _, p := range service.AllPeriods {
-		if opts.Period == p {
-			validPeriod = true
-			break
+	if opts.From == nil && !service.IsValidPeriod(opts.Period) {
+		return fmt.Errorf("invalid period: %s\nValid periods: %v", opts.Period, service.AllPeriods)
+	}
+
+	// Show every day of short periods in the table, so a week reads as a complete grid
+	if reportHeatmap {
+		opts.FillZeroDays = true
+	} else if reportFormat == "table" {
+		start, end := opts.DateRange()
+		if end.Sub(start) <= maxFilledDays*24*time.Hour {
+			opts.FillZeroDays = true
 		}
 	}
-	if !validPeriod {
-		return fmt.Errorf("invalid period: %s\nValid periods: %v", opts.Period, service.AllPeriods)
+
+	proceed, err := checkMaxEntries(service.EntryFilter(opts), reportMaxEntries)
+	if err != nil || !proceed {
+		return err
 	}
 
 	// Generate report
@@ -142,15 +368,177 @@ func runReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
-	// Output report
+	var w io.Writer = os.Stdout
+	var out *os.File
+	if reportOutput != "" {
+		f, err := createOutputFile(reportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w, out = f, f
+
+		// A file is never a terminal, so only color it when asked to explicitly
+		if colorMode == color.Auto {
+			if err := color.Setup(color.Never); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeReport(w, summary); err != nil {
+		return err
+	}
+	if out != nil {
+		// Closing can report a failed write, so the report only counts as written once it succeeds
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s report to %s\n", reportFormat, reportOutput)
+	}
+	return nil
+}
+
+// writeReport writes summary to w in the format selected by --format, as a timeline of events with
+// --entries-as-events, or as per-day heatmap data with --heatmap.
+func writeReport(w io.Writer, summary *model.ReportSummary) error {
+	if reportHeatmap {
+		return outputHeatmap(w, summary)
+	}
+	if reportEntriesAsEvents {
+		entries := make([]model.Entry, len(summary.Entries))
+		for i, e := range summary.Entries {
+			entries[i] = e.Entry
+		}
+		return outputTimeline(w, service.Timeline(entries))
+	}
+
 	switch reportFormat {
 	case "json":
-		return outputJSON(summary)
+		return outputJSON(w, summary)
 	case "csv":
-		return outputCSV(summary)
+		if reportDetailed {
+			return outputDetailedCSV(w, summary)
+		}
+		return outputCSV(w, summary)
+	case "markdown":
+		return outputMarkdown(w, summary)
+	case "html":
+		return outputHTML(w, summary)
 	default:
-		return outputTable(summary)
+		if summary.Groups != nil {
+			return outputGroupedTable(w, summary)
+		}
+		return outputTable(w, summary)
+	}
+}
+
+// createOutputFile creates the file at path for writing, along with any missing parent directories. An existing
+// file is truncated.
+func createOutputFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, nil
+}
+
+// parseReportRange sets opts.From and opts.To from the --from, --since, and --to flags.
+//
+// Both dates are parsed in local time and --to is inclusive, so opts.To is midnight after it. A missing --to means
+// today. --since sets opts.From to that long before now, in place of --from.
+//
+// Returns an error if neither --from nor --since is given, both are, a value is malformed, or --to is before the
+// start.
+func parseReportRange(opts *service.ReportOptions) error {
+	from, err := parseRangeStart(reportFrom, reportSince)
+	if err != nil {
+		return err
+	}
+	if from == nil {
+		return fmt.Errorf("--to requires --from or --since")
+	}
+
+	now := db.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, db.Location)
+	if reportTo != "" {
+		to, err = time.ParseInLocation("2006-01-02", reportTo, db.Location)
+		if err != nil {
+			return fmt.Errorf("invalid --to date (use YYYY-MM-DD): %w", err)
+		}
+	}
+	if !to.AddDate(0, 0, 1).After(*from) {
+		return fmt.Errorf("--to (%s) must not be before the start (%s)", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	// Add a day to include the entire 'to' date
+	to = to.AddDate(0, 0, 1)
+	opts.From = from
+	opts.To = &to
+	return nil
+}
+
+// parseRangeStart returns the start of a date range given by a --from date or a --since duration, or nil if neither
+// is set. The date is parsed in local time; the duration is subtracted from now (see [parseRelativeDuration]).
+//
+// Returns an error if both are set or the value is malformed.
+func parseRangeStart(from, since string) (*time.Time, error) {
+	if since != "" {
+		if from != "" {
+			return nil, fmt.Errorf("--since cannot be combined with --from")
+		}
+		d, err := parseRelativeDuration(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+		t := db.Now().Add(-d)
+		return &t, nil
+	}
+	if from == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", from, db.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from date (use YYYY-MM-DD): %w", err)
+	}
+	return &t, nil
+}
+
+// parseRelativeDuration parses a whole number of hours, days, or weeks, such as "12h", "3d", or "2w". A day is 24
+// hours and a week is 7 days.
+//
+// Returns an error if the value is not a positive number followed by h, d, or w.
+func parseRelativeDuration(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+	if len(value) < 2 {
+		return 0, fmt.Errorf("%q is not a duration like 12h, 3d, or 2w", value)
+	}
+	unit, ok := units[value[len(value)-1]]
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if !ok || err != nil || n <= 0 {
+		return 0, fmt.Errorf("%q is not a duration like 12h, 3d, or 2w", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// isValidGroupBy reports whether g is empty or one of [service.AllGroupBys].
+func isValidGroupBy(g service.GroupBy) bool {
+	if g == service.GroupByNone {
+		return true
+	}
+	for _, valid := range service.AllGroupBys {
+		if g == valid {
+			return true
+		}
 	}
+	return false
 }
 
 // selectPeriod prompts the user to select a reporting period interactively.
@@ -197,17 +585,26 @@ func selectPeriod() (service.Period, error) {
 // summary contains aggregated report details including total duration, grouped data by tags and projects, and individual entries.
 // If summary contains no entries or group data, only the total duration is printed.
 //
+// Project names are colored when colors are on (see [color.Setup]); the other formats are always written without
+// escape codes.
+//
 // Returns nil upon successful execution or an error if there is an issue with the output generation.
-func outputTable(summary *model.ReportSummary) error {
-	fmt.Printf("\nReport: %s\n", summary.Period)
-	fmt.Printf("Period: %s to %s\n\n",
+func outputTable(w io.Writer, summary *model.ReportSummary) error {
+	fmt.Fprintf(w, "\nReport: %s\n", summary.Period)
+	fmt.Fprintf(w, "Period: %s to %s\n\n",
 		summary.StartDate.Format("2006-01-02"),
 		summary.EndDate.Add(-1).Format("2006-01-02"))
 
 	if len(summary.Entries) > 0 {
-		fmt.Println("Entries:")
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "Project", "Title", "Duration", "Tags", "Date"})
+		fmt.Fprintln(w, "Entries:")
+		// Estimate columns are only shown when some entry has an estimate
+		estimates := summary.EstimatedDuration > 0
+		header := []string{"ID", "Project", "Title", "Duration", "Tags", "Date"}
+		if estimates {
+			header = slices.Insert(header, 4, "Estimate", "Variance")
+		}
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(header)
 		table.SetBorder(false)
 		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 		table.SetAlignment(tablewriter.ALIGN_LEFT)
@@ -224,54 +621,307 @@ func outputTable(summary *model.ReportSummary) error {
 			if len(title) > 35 {
 				title = title[:32] + "..."
 			}
-			table.Append([]string{
-				e.ID,
-				"@" + e.ProjectName,
+			row := []string{
+				color.Dim(shortID(e.ID)),
+				color.Project(e.ProjectName),
 				title,
 				formatDurationShort(e.Duration),
 				strings.Join(e.TagNames, ", "),
 				e.StartTime.Format("2006-01-02 15:04"),
-			})
+			}
+			if estimates {
+				estimate, variance := "", ""
+				if e.Estimate != nil {
+					estimate = formatDurationShort(*e.Estimate)
+					variance = formatDeltaShort(e.Duration - *e.Estimate)
+				}
+				row = slices.Insert(row, 4, estimate, variance)
+			}
+			table.Append(row)
 		}
 		table.Render()
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(summary.ByProject) > 0 {
-		fmt.Println("By Project:")
-		table := tablewriter.NewWriter(os.Stdout)
+		fmt.Fprintln(w, "By Project:")
+		table := tablewriter.NewWriter(w)
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetColumnSeparator("")
+		table.SetTablePadding("  ")
+		// Escape codes would otherwise make the colored names wrap
+		table.SetAutoWrapText(false)
+
+		for _, name := range service.SortByDuration(summary.ByProject) {
+			label := breakdownLabel("@", name)
+			if name != service.OtherKey {
+				label = color.Project(name)
+			}
+			row := []string{"  " + label, formatDurationShort(summary.ByProject[name])}
+			if summary.ByProjectAmount != nil {
+				row = append(row, "")
+				if amount, ok := summary.ByProjectAmount[name]; ok {
+					row[2] = formatAmount(summary.Currency, amount)
+				}
+			}
+			table.Append(row)
+		}
+		table.Render()
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByDay) > 0 {
+		fmt.Fprintln(w, "By Day:")
+		table := tablewriter.NewWriter(w)
 		table.SetBorder(false)
 		table.SetHeaderLine(false)
 		table.SetColumnSeparator("")
 		table.SetTablePadding("  ")
 
-		for name, dur := range summary.ByProject {
-			table.Append([]string{"  @" + name, formatDurationShort(dur)})
+		for _, day := range sortedKeys(summary.ByDay) {
+			label := day
+			if t, err := time.ParseInLocation(service.DayKeyFormat, day, db.Location); err == nil {
+				label = t.Format("Mon 2006-01-02")
+			}
+			table.Append([]string{"  " + label, formatDurationShort(summary.ByDay[day])})
 		}
 		table.Render()
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(summary.ByTag) > 0 {
-		fmt.Println("By Tag:")
-		table := tablewriter.NewWriter(os.Stdout)
+		fmt.Fprintln(w, "By Tag:")
+		table := tablewriter.NewWriter(w)
 		table.SetBorder(false)
 		table.SetHeaderLine(false)
 		table.SetColumnSeparator("")
 		table.SetTablePadding("  ")
 
-		for name, dur := range summary.ByTag {
-			table.Append([]string{"  +" + name, formatDurationShort(dur)})
+		for _, name := range service.SortByDuration(summary.ByTag) {
+			table.Append([]string{"  " + breakdownLabel("+", name), formatDurationShort(summary.ByTag[name])})
 		}
 		table.Render()
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
-	fmt.Printf("Total: %s\n", formatDuration(summary.TotalDuration))
+	if len(summary.ByTagSet) > 0 {
+		fmt.Fprintln(w, "By Tag Combination:")
+		table := tablewriter.NewWriter(w)
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetColumnSeparator("")
+		table.SetTablePadding("  ")
+
+		for _, key := range service.SortByDuration(summary.ByTagSet) {
+			label := "(no tags)"
+			if key != "" {
+				label = formatTags(strings.Split(key, ","))
+			}
+			table.Append([]string{"  " + label, formatDurationShort(summary.ByTagSet[key])})
+		}
+		table.Render()
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByTagPrefix) > 0 {
+		fmt.Fprintln(w, "By Tag Prefix:")
+		table := tablewriter.NewWriter(w)
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetColumnSeparator("")
+		table.SetTablePadding("  ")
+
+		totals := make(map[string]time.Duration, len(summary.ByTagPrefix))
+		for prefix, values := range summary.ByTagPrefix {
+			for _, dur := range values {
+				totals[prefix] += dur
+			}
+		}
+		for _, prefix := range service.SortByDuration(totals) {
+			table.Append([]string{"  +" + prefix, formatDurationShort(totals[prefix])})
+			values := summary.ByTagPrefix[prefix]
+			for _, value := range service.SortByDuration(values) {
+				if value == "" {
+					continue
+				}
+				table.Append([]string{"      " + value, formatDurationShort(values[value])})
+			}
+		}
+		table.Render()
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByPauseReason) > 0 {
+		fmt.Fprintln(w, "Pauses by Reason:")
+		table := tablewriter.NewWriter(w)
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetColumnSeparator("")
+		table.SetTablePadding("  ")
+
+		for _, reason := range service.SortByDuration(summary.ByPauseReason) {
+			table.Append([]string{"  " + reason, formatDurationShort(summary.ByPauseReason[reason])})
+		}
+		table.Render()
+		fmt.Fprintln(w)
+	}
+
+	printReportTotals(w, summary)
+	return nil
+}
+
+// printReportTotals prints the total duration and amount of a table report, followed by the reconciliation of raw and
+// adjusted totals when report adjustments changed any duration. The billable split is shown only when some of the
+// time is not billable, the billed split only when billed entries are included, and the estimate variance only when
+// some entry has an estimate.
+func printReportTotals(w io.Writer, summary *model.ReportSummary) {
+	fmt.Fprintf(w, "Total: %s\n", formatDuration(summary.TotalDuration))
+	if summary.NonBillableDuration > 0 {
+		fmt.Fprintf(w, "Billable: %s, non-billable: %s\n",
+			formatDuration(summary.BillableDuration), formatDuration(summary.NonBillableDuration))
+	}
+	if summary.BilledDuration > 0 {
+		fmt.Fprintf(w, "Billed: %s, unbilled: %s\n",
+			formatDuration(summary.BilledDuration), formatDuration(summary.UnbilledDuration))
+	}
+	if summary.ByProjectAmount != nil {
+		fmt.Fprintf(w, "Amount: %s\n", formatAmount(summary.Currency, summary.TotalAmount))
+	}
+	if summary.EstimatedDuration > 0 {
+		fmt.Fprintf(w, "Estimated: %s, actual: %s (%s)\n", formatDuration(summary.EstimatedDuration),
+			formatDuration(summary.EstimatedActual), formatDelta(summary.EstimatedActual-summary.EstimatedDuration))
+	}
+
+	if note := activeEntriesNote(summary); note != "" {
+		fmt.Fprintf(w, "Note: %s\n", note)
+	}
+
+	if summary.Adjusted() {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Reconciliation:")
+		fmt.Fprintf(w, "  Raw total:       %s\n", formatDuration(summary.RawTotal))
+		fmt.Fprintf(w, "  Adjusted total:  %s\n", formatDuration(summary.AdjustedTotal))
+		fmt.Fprintf(w, "  Difference:      %s\n", formatDelta(summary.AdjustedTotal-summary.RawTotal))
+		fmt.Fprintf(w, "  Reason:          %s\n", summary.AdjustmentReason)
+	}
+}
+
+// activeEntriesNote returns a note that the durations of the running or paused entries in summary are as of the time
+// the report was generated, or an empty string if there are none.
+func activeEntriesNote(summary *model.ReportSummary) string {
+	if summary.ActiveEntries == 0 || summary.AsOf == nil {
+		return ""
+	}
+	noun := "entry"
+	if summary.ActiveEntries > 1 {
+		noun = "entries"
+	}
+	return fmt.Sprintf("includes %d running or paused %s, measured as of %s (use --include-running=false to leave out)",
+		summary.ActiveEntries, noun, summary.AsOf.Format("2006-01-02 15:04"))
+}
+
+// outputGroupedTable prints a report whose only breakdown is the headline grouping in summary.Groups, followed by the
+// same totals and reconciliation as [outputTable].
+func outputGroupedTable(w io.Writer, summary *model.ReportSummary) error {
+	fmt.Fprintf(w, "\nReport: %s\n", summary.Period)
+	fmt.Fprintf(w, "Period: %s to %s\n\n",
+		summary.StartDate.Format("2006-01-02"),
+		summary.EndDate.Add(-1).Format("2006-01-02"))
+
+	titles := map[string]string{"day": "By Day", "week": "By Week", "project": "By Project", "tag": "By Tag"}
+	fmt.Fprintf(w, "%s:\n", titles[summary.GroupBy])
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetColumnSeparator("")
+	table.SetTablePadding("  ")
+
+	keys := sortedKeys(summary.Groups)
+	if summary.GroupBy == "project" || summary.GroupBy == "tag" {
+		keys = service.SortByDuration(summary.Groups)
+	}
+	for _, key := range keys {
+		label := key
+		switch summary.GroupBy {
+		case "day", "week":
+			if t, err := time.ParseInLocation(service.DayKeyFormat, key, db.Location); err == nil {
+				label = t.Format("Mon 2006-01-02")
+				if summary.GroupBy == "week" {
+					label = "Week of " + t.Format("2006-01-02")
+				}
+			}
+		case "project":
+			label = breakdownLabel("@", key)
+		case "tag":
+			label = breakdownLabel("+", key)
+		}
+		table.Append([]string{"  " + label, formatDurationShort(summary.Groups[key])})
+	}
+	table.Render()
+	fmt.Fprintln(w)
 
+	printReportTotals(w, summary)
 	return nil
 }
 
+// parseRounding parses a rounding increment such as "15m". "0" or an empty value disables rounding.
+//
+// Returns an error if the value is not a valid non-negative duration.
+func parseRounding(value string) (time.Duration, error) {
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("value must be a duration like 5m, 15m, or 1h, or 0 to disable")
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("value must not be negative")
+	}
+	return d, nil
+}
+
+// sortedKeys returns the keys of a breakdown in sorted order, which is chronological for per-day breakdowns.
+func sortedKeys(byDay map[string]time.Duration) []string {
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	return days
+}
+
+// breakdownLabel returns name with sigil in front for display in a per-project or per-tag breakdown, except for
+// [service.OtherKey], which is not a project or tag name.
+func breakdownLabel(sigil, name string) string {
+	if name == service.OtherKey {
+		return name
+	}
+	return sigil + name
+}
+
+// formatAmount formats a billable amount with two decimals after the currency symbol, e.g. "$240.00".
+func formatAmount(currency string, amount float64) string {
+	return fmt.Sprintf("%s%.2f", currency, amount)
+}
+
+// formatDelta formats a signed duration difference with an explicit "+" or "-" sign.
+func formatDelta(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDuration(-d)
+	}
+	return "+" + formatDuration(d)
+}
+
+// formatDeltaShort formats a signed difference like [formatDelta], rounded to minutes with [formatDurationShort].
+func formatDeltaShort(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDurationShort(-d)
+	}
+	return "+" + formatDurationShort(d)
+}
+
 // outputJSON writes the provided [model.ReportSummary] to the standard output in JSON format with indentation.
 //
 // The function uses a JSON encoder to serialize the [model.ReportSummary] object and ensures the output is formatted
@@ -280,8 +930,8 @@ func outputTable(summary *model.ReportSummary) error {
 // summary is the [model.ReportSummary] to be serialized and output.
 //
 // Returns an error if the encoding process fails, which might indicate issues such as the inability to write to stdout.
-func outputJSON(summary *model.ReportSummary) error {
-	encoder := json.NewEncoder(os.Stdout)
+func outputJSON(w io.Writer, summary *model.ReportSummary) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(summary)
 }
@@ -292,36 +942,576 @@ func outputJSON(summary *model.ReportSummary) error {
 // - the entry ID,
 // - project name,
 // - title,
-// - duration in minutes,
+// - gross duration in minutes (end minus start),
+// - net duration in minutes (pauses subtracted, after report adjustments such as rounding),
 // - associated tags,
-// - start time, and
-// - end time (if available).
+// - start time,
+// - end time,
+// - status, and
+// - note.
+//
+// Entries still running are measured up to the end of the report period, or up to now if the period has not ended.
+// Their end time is that moment and their status is "running" or "paused", so the durations always match the times.
 //
+// The per-day, per-project, and per-tag totals and the reconciliation footer follow, as written by [writeCSVSummary].
 // If the report summary contains no entries, only the header row will be written.
 //
+// With --no-header, only the entry rows are written, leaving out the header row and the summary sections, each of
+// which has a header of its own. Fields are separated by --delimiter (see [newReportCSVWriter]).
+//
 // Returns an error if writing to the CSV writer fails. Use [csv.NewWriter] for output formatting consistency.
-func outputCSV(summary *model.ReportSummary) error {
-	writer := csv.NewWriter(os.Stdout)
+func outputCSV(w io.Writer, summary *model.ReportSummary) error {
+	writer := newReportCSVWriter(w)
 	defer writer.Flush()
 
-	// Header
-	writer.Write([]string{"ID", "Project", "Title", "Duration (minutes)", "Tags", "Start", "End"})
+	asOf := reportAsOf(summary)
+	if !reportNoHeader {
+		writer.Write(entryCSVHeader)
+	}
+	for _, e := range summary.Entries {
+		writer.Write(entryCSVRow(e.Entry, e.Duration, asOf))
+	}
+
+	if !reportNoHeader {
+		writeCSVSummary(writer, summary)
+	}
+	return nil
+}
+
+// csvDelimiters maps the accepted --delimiter values to the field separators they select.
+var csvDelimiters = map[string]rune{
+	",":   ',',
+	";":   ';',
+	"|":   '|',
+	"tab": '\t',
+}
+
+// parseCSVDelimiter returns the field separator selected by a --delimiter value from [csvDelimiters]. A literal tab
+// is accepted as well as "tab".
+//
+// Returns an error if the value is not one of them.
+func parseCSVDelimiter(value string) (rune, error) {
+	if value == "\t" {
+		return '\t', nil
+	}
+	comma, ok := csvDelimiters[strings.ToLower(value)]
+	if !ok {
+		return 0, fmt.Errorf("invalid --delimiter: %q (use ',', ';', '|', or 'tab')", value)
+	}
+	return comma, nil
+}
+
+// newReportCSVWriter returns a [csv.Writer] on w that separates fields with the --delimiter validated by
+// [runReport].
+func newReportCSVWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if comma, err := parseCSVDelimiter(reportDelimiter); err == nil {
+		writer.Comma = comma
+	}
+	return writer
+}
+
+// writeCSVSummary writes the sections that follow the entries in CSV reports, each after a blank row: the per-day
+// totals in date order, the per-project and per-tag totals with the most time first, the number of running or paused
+// entries and the time they were measured at, and the reconciliation of raw and adjusted totals when report
+// adjustments changed any duration.
+func writeCSVSummary(writer *csv.Writer, summary *model.ReportSummary) {
+	if len(summary.ByDay) > 0 {
+		writer.Write([]string{})
+		writer.Write([]string{"Date", "Duration (minutes)"})
+		for _, day := range sortedKeys(summary.ByDay) {
+			writer.Write([]string{day, fmt.Sprintf("%.1f", summary.ByDay[day].Minutes())})
+		}
+	}
+
+	if len(summary.ByProject) > 0 {
+		writer.Write([]string{})
+		writer.Write([]string{"Project", "Duration (minutes)"})
+		for _, name := range service.SortByDuration(summary.ByProject) {
+			writer.Write([]string{name, fmt.Sprintf("%.1f", summary.ByProject[name].Minutes())})
+		}
+	}
 
+	if len(summary.ByTag) > 0 {
+		writer.Write([]string{})
+		writer.Write([]string{"Tag", "Duration (minutes)"})
+		for _, name := range service.SortByDuration(summary.ByTag) {
+			writer.Write([]string{name, fmt.Sprintf("%.1f", summary.ByTag[name].Minutes())})
+		}
+	}
+
+	if len(summary.ByPauseReason) > 0 {
+		writer.Write([]string{})
+		writer.Write([]string{"Pause reason", "Duration (minutes)"})
+		for _, reason := range service.SortByDuration(summary.ByPauseReason) {
+			writer.Write([]string{reason, fmt.Sprintf("%.1f", summary.ByPauseReason[reason].Minutes())})
+		}
+	}
+
+	if summary.AsOf != nil {
+		writer.Write([]string{})
+		writer.Write([]string{"Active entries", fmt.Sprintf("%d", summary.ActiveEntries)})
+		writer.Write([]string{"Measured as of", summary.AsOf.Format("2006-01-02 15:04:05")})
+	}
+
+	if summary.Adjusted() {
+		writer.Write([]string{})
+		writer.Write([]string{"Raw total (minutes)", fmt.Sprintf("%.1f", summary.RawTotal.Minutes())})
+		writer.Write([]string{"Adjusted total (minutes)", fmt.Sprintf("%.1f", summary.AdjustedTotal.Minutes())})
+		writer.Write([]string{"Difference (minutes)", fmt.Sprintf("%.1f", (summary.AdjustedTotal - summary.RawTotal).Minutes())})
+		writer.Write([]string{"Adjustment reason", summary.AdjustmentReason})
+	}
+}
+
+// outputDetailedCSV writes summary as CSV like [outputCSV], but lists each entry's pauses in rows of their own right
+// after it, so the time subtracted between the gross and net durations is visible.
+//
+// Every row starts with its type, "entry" or "pause", and the ID of the entry it belongs to, followed by the
+// [entryCSVHeader] columns and the pause reason. Pause rows leave the project, title, tags, and note empty, and report
+// the length of the pause as the gross duration and "open" or "resumed" as the status. A pause still open is measured
+// up to the same moment as its entry.
+func outputDetailedCSV(w io.Writer, summary *model.ReportSummary) error {
+	writer := newReportCSVWriter(w)
+	defer writer.Flush()
+
+	asOf := reportAsOf(summary)
+	if !reportNoHeader {
+		header := append([]string{"Type", "Entry ID"}, entryCSVHeader...)
+		writer.Write(append(header, "Reason"))
+	}
 	for _, e := range summary.Entries {
-		endTime := ""
-		if e.EndTime != nil {
-			endTime = e.EndTime.Format("2006-01-02 15:04:05")
-		}
-
-		writer.Write([]string{
-			e.ID,
-			e.ProjectName,
-			e.Title,
-			fmt.Sprintf("%.1f", e.Duration.Minutes()),
-			strings.Join(e.TagNames, ","),
-			e.StartTime.Format("2006-01-02 15:04:05"),
-			endTime,
-		})
+		row := append([]string{"entry", e.ID}, entryCSVRow(e.Entry, e.Duration, asOf)...)
+		writer.Write(append(row, ""))
+
+		for _, p := range e.Pauses {
+			resumeTime, status := asOf, "open"
+			if p.ResumeTime != nil {
+				resumeTime, status = *p.ResumeTime, "resumed"
+			}
+			writer.Write([]string{
+				"pause",
+				p.EntryID,
+				p.ID,
+				"",
+				"",
+				fmt.Sprintf("%.1f", resumeTime.Sub(p.PauseTime).Minutes()),
+				"",
+				"",
+				p.PauseTime.Format("2006-01-02 15:04:05"),
+				resumeTime.Format("2006-01-02 15:04:05"),
+				status,
+				"",
+				"",
+				"",
+				p.Reason,
+			})
+		}
+	}
+
+	if !reportNoHeader {
+		writeCSVSummary(writer, summary)
+	}
+	return nil
+}
+
+// entryCSVHeader is the header of the entry rows written by [entryCSVRow]. The duration columns spell out what they
+// measure, since a spreadsheet summing them should not need the documentation.
+var entryCSVHeader = []string{
+	"ID",
+	"Project",
+	"Title",
+	"Gross Duration (minutes, end - start)",
+	"Net Duration (minutes, excluding pauses)",
+	"Tags",
+	"Start",
+	"End",
+	"Status",
+	"Note",
+	"Estimate (minutes)",
+	"Variance (minutes, net - estimate)",
+}
+
+// entryCSVRow formats e as a CSV row matching [entryCSVHeader], reporting net rather than [model.Entry.Duration] so
+// reports can pass adjusted durations.
+//
+// An entry that has not stopped is measured up to asOf, which is written as its end time; its status tells it apart.
+// The estimate columns are empty for entries without an estimate.
+func entryCSVRow(e model.Entry, net time.Duration, asOf time.Time) []string {
+	projectName := ""
+	if e.Project != nil {
+		projectName = e.Project.Name
+	}
+	tagNames := make([]string, len(e.Tags))
+	for i, t := range e.Tags {
+		tagNames[i] = t.Name
+	}
+	endTime := asOf
+	if e.EndTime != nil {
+		endTime = *e.EndTime
+	}
+	estimate, variance := "", ""
+	if e.Estimate != nil {
+		estimate = fmt.Sprintf("%.1f", e.Estimate.Minutes())
+		variance = fmt.Sprintf("%.1f", (net - *e.Estimate).Minutes())
+	}
+
+	return []string{
+		e.ID,
+		projectName,
+		e.Title,
+		fmt.Sprintf("%.1f", endTime.Sub(e.StartTime).Minutes()),
+		fmt.Sprintf("%.1f", net.Minutes()),
+		strings.Join(tagNames, ","),
+		e.StartTime.Format("2006-01-02 15:04:05"),
+		endTime.Format("2006-01-02 15:04:05"),
+		string(e.Status),
+		e.Note,
+		estimate,
+		variance,
+	}
+}
+
+// reportAsOf returns the moment entries still running are measured up to in summary: the end of the report period,
+// or now if the period has not ended yet. This matches the clamping applied to their durations by the report.
+func reportAsOf(summary *model.ReportSummary) time.Time {
+	now := db.Now()
+	if summary.EndDate.Before(now) {
+		return summary.EndDate
+	}
+	return now
+}
+
+// outputMarkdown writes summary as GitHub-flavored Markdown: a heading with the period, then separate tables for the
+// entries, the per-project totals, and the per-tag totals, and the total duration.
+func outputMarkdown(w io.Writer, summary *model.ReportSummary) error {
+	cell := func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	}
+
+	fmt.Fprintf(w, "## Report: %s\n\n", cell(summary.Period))
+	fmt.Fprintf(w, "%s to %s\n\n", summary.StartDate.Format("2006-01-02"), summary.EndDate.Add(-1).Format("2006-01-02"))
+
+	if len(summary.Entries) > 0 {
+		fmt.Fprintln(w, "### Entries")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| ID | Project | Title | Duration | Tags | Date |")
+		fmt.Fprintln(w, "|----|---------|-------|----------|------|------|")
+		for _, e := range summary.Entries {
+			fmt.Fprintf(w, "| %s | @%s | %s | %s | %s | %s |\n",
+				e.ID, cell(e.ProjectName), cell(e.Title), formatDurationShort(e.Duration),
+				cell(strings.Join(e.TagNames, ", ")), e.StartTime.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByProject) > 0 {
+		fmt.Fprintln(w, "### By Project")
+		fmt.Fprintln(w)
+		if summary.ByProjectAmount != nil {
+			fmt.Fprintln(w, "| Project | Duration | Amount |")
+			fmt.Fprintln(w, "|---------|----------|--------|")
+		} else {
+			fmt.Fprintln(w, "| Project | Duration |")
+			fmt.Fprintln(w, "|---------|----------|")
+		}
+		for _, name := range service.SortByDuration(summary.ByProject) {
+			fmt.Fprintf(w, "| %s | %s |", cell(breakdownLabel("@", name)), formatDurationShort(summary.ByProject[name]))
+			if summary.ByProjectAmount != nil {
+				amount := ""
+				if a, ok := summary.ByProjectAmount[name]; ok {
+					amount = formatAmount(summary.Currency, a)
+				}
+				fmt.Fprintf(w, " %s |", amount)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByTag) > 0 {
+		fmt.Fprintln(w, "### By Tag")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Tag | Duration |")
+		fmt.Fprintln(w, "|-----|----------|")
+		for _, name := range service.SortByDuration(summary.ByTag) {
+			fmt.Fprintf(w, "| %s | %s |\n", cell(breakdownLabel("+", name)), formatDurationShort(summary.ByTag[name]))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.ByPauseReason) > 0 {
+		fmt.Fprintln(w, "### Pauses by Reason")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Reason | Duration |")
+		fmt.Fprintln(w, "|--------|----------|")
+		for _, reason := range service.SortByDuration(summary.ByPauseReason) {
+			fmt.Fprintf(w, "| %s | %s |\n", cell(reason), formatDurationShort(summary.ByPauseReason[reason]))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "**Total:** %s\n", formatDuration(summary.TotalDuration))
+	if summary.NonBillableDuration > 0 {
+		fmt.Fprintf(w, "\n**Billable:** %s, **non-billable:** %s\n",
+			formatDuration(summary.BillableDuration), formatDuration(summary.NonBillableDuration))
+	}
+	if summary.BilledDuration > 0 {
+		fmt.Fprintf(w, "\n**Billed:** %s, **unbilled:** %s\n",
+			formatDuration(summary.BilledDuration), formatDuration(summary.UnbilledDuration))
+	}
+	if summary.ByProjectAmount != nil {
+		fmt.Fprintf(w, "\n**Amount:** %s\n", formatAmount(summary.Currency, summary.TotalAmount))
+	}
+	if summary.EstimatedDuration > 0 {
+		fmt.Fprintf(w, "\n**Estimated:** %s, **actual:** %s (%s)\n", formatDuration(summary.EstimatedDuration),
+			formatDuration(summary.EstimatedActual), formatDelta(summary.EstimatedActual-summary.EstimatedDuration))
+	}
+	if note := activeEntriesNote(summary); note != "" {
+		fmt.Fprintf(w, "\n_Note: %s_\n", note)
+	}
+	return nil
+}
+
+// outputHTML writes summary as a standalone HTML document with the same sections as [outputMarkdown].
+func outputHTML(w io.Writer, summary *model.ReportSummary) error {
+	esc := html.EscapeString
+	row := func(tag string, cells ...string) {
+		fmt.Fprint(w, "<tr>")
+		for _, c := range cells {
+			fmt.Fprintf(w, "<%s>%s</%s>", tag, esc(c), tag)
+		}
+		fmt.Fprintln(w, "</tr>")
+	}
+
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, "<html>")
+	fmt.Fprintf(w, "<head><meta charset=\"utf-8\"><title>Report: %s</title></head>\n", esc(summary.Period))
+	fmt.Fprintln(w, "<body>")
+	fmt.Fprintf(w, "<h2>Report: %s</h2>\n", esc(summary.Period))
+	fmt.Fprintf(w, "<p>%s to %s</p>\n", summary.StartDate.Format("2006-01-02"), summary.EndDate.Add(-1).Format("2006-01-02"))
+
+	if len(summary.Entries) > 0 {
+		fmt.Fprintln(w, "<h3>Entries</h3>")
+		fmt.Fprintln(w, "<table>")
+		row("th", "ID", "Project", "Title", "Duration", "Tags", "Date")
+		for _, e := range summary.Entries {
+			row("td", e.ID, "@"+e.ProjectName, e.Title, formatDurationShort(e.Duration),
+				strings.Join(e.TagNames, ", "), e.StartTime.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintln(w, "</table>")
+	}
+
+	if len(summary.ByProject) > 0 {
+		fmt.Fprintln(w, "<h3>By Project</h3>")
+		fmt.Fprintln(w, "<table>")
+		if summary.ByProjectAmount != nil {
+			row("th", "Project", "Duration", "Amount")
+		} else {
+			row("th", "Project", "Duration")
+		}
+		for _, name := range service.SortByDuration(summary.ByProject) {
+			cells := []string{breakdownLabel("@", name), formatDurationShort(summary.ByProject[name])}
+			if summary.ByProjectAmount != nil {
+				amount := ""
+				if a, ok := summary.ByProjectAmount[name]; ok {
+					amount = formatAmount(summary.Currency, a)
+				}
+				cells = append(cells, amount)
+			}
+			row("td", cells...)
+		}
+		fmt.Fprintln(w, "</table>")
+	}
+
+	if len(summary.ByTag) > 0 {
+		fmt.Fprintln(w, "<h3>By Tag</h3>")
+		fmt.Fprintln(w, "<table>")
+		row("th", "Tag", "Duration")
+		for _, name := range service.SortByDuration(summary.ByTag) {
+			row("td", breakdownLabel("+", name), formatDurationShort(summary.ByTag[name]))
+		}
+		fmt.Fprintln(w, "</table>")
+	}
+
+	if len(summary.ByPauseReason) > 0 {
+		fmt.Fprintln(w, "<h3>Pauses by Reason</h3>")
+		fmt.Fprintln(w, "<table>")
+		row("th", "Reason", "Duration")
+		for _, reason := range service.SortByDuration(summary.ByPauseReason) {
+			row("td", reason, formatDurationShort(summary.ByPauseReason[reason]))
+		}
+		fmt.Fprintln(w, "</table>")
+	}
+
+	fmt.Fprintf(w, "<p><strong>Total:</strong> %s</p>\n", formatDuration(summary.TotalDuration))
+	if summary.NonBillableDuration > 0 {
+		fmt.Fprintf(w, "<p><strong>Billable:</strong> %s, <strong>non-billable:</strong> %s</p>\n",
+			formatDuration(summary.BillableDuration), formatDuration(summary.NonBillableDuration))
+	}
+	if summary.BilledDuration > 0 {
+		fmt.Fprintf(w, "<p><strong>Billed:</strong> %s, <strong>unbilled:</strong> %s</p>\n",
+			formatDuration(summary.BilledDuration), formatDuration(summary.UnbilledDuration))
+	}
+	if summary.ByProjectAmount != nil {
+		fmt.Fprintf(w, "<p><strong>Amount:</strong> %s</p>\n", esc(formatAmount(summary.Currency, summary.TotalAmount)))
+	}
+	if summary.EstimatedDuration > 0 {
+		fmt.Fprintf(w, "<p><strong>Estimated:</strong> %s, <strong>actual:</strong> %s (%s)</p>\n",
+			formatDuration(summary.EstimatedDuration), formatDuration(summary.EstimatedActual),
+			formatDelta(summary.EstimatedActual-summary.EstimatedDuration))
+	}
+	if note := activeEntriesNote(summary); note != "" {
+		fmt.Fprintf(w, "<p><em>Note: %s</em></p>\n", esc(note))
+	}
+	fmt.Fprintln(w, "</body>")
+	fmt.Fprintln(w, "</html>")
+	return nil
+}
+
+// heatmapLevels are the cells of the [outputHeatmap] grid for a day without tracked time, followed by four levels of
+// increasing time, relative to the busiest day.
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// outputHeatmap writes the per-day totals of summary, which has every day of the period filled in, for a
+// contribution-style heatmap.
+//
+// JSON emits an object mapping each date in [service.DayKeyFormat] to the whole minutes tracked that day. The table is
+// a grid with a row per month and a column per day of the month, each cell shaded by [heatmapLevels], followed by
+// the month's total, for example:
+//
+//	          1        10        20        30
+//	Jan 2024  ·░▒▓█··░░▒▒▓▓██···░▒▓█··░░▒▒▓·  86h 30m
+//
+// Returns an error if writing the output fails.
+func outputHeatmap(w io.Writer, summary *model.ReportSummary) error {
+	if reportFormat == "json" {
+		minutes := make(map[string]int, len(summary.ByDay))
+		for day, d := range summary.ByDay {
+			minutes[day] = int(d.Minutes())
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(minutes)
+	}
+
+	var busiest time.Duration
+	for _, d := range summary.ByDay {
+		busiest = max(busiest, d)
+	}
+
+	fmt.Fprintf(w, "\nHeatmap: %s\n", summary.Period)
+	fmt.Fprintf(w, "Period: %s to %s\n\n",
+		summary.StartDate.Format("2006-01-02"),
+		summary.EndDate.Add(-1).Format("2006-01-02"))
+
+	// Day numbers over the columns of days 1, 10, 20, and 30
+	fmt.Fprintf(w, "%-10s%-9s%-10s%-10s%s\n", "", "1", "10", "20", "30")
+
+	start := summary.StartDate
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()); month.Before(summary.EndDate); month = month.AddDate(0, 1, 0) {
+		var row strings.Builder
+		var total time.Duration
+		for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+			d, ok := summary.ByDay[day.Format(service.DayKeyFormat)]
+			if !ok {
+				// Outside the period
+				row.WriteString(" ")
+				continue
+			}
+			total += d
+			level := 0
+			if d > 0 && busiest > 0 {
+				level = min(int((4*d+busiest-1)/busiest), 4)
+			}
+			row.WriteString(heatmapLevels[level])
+		}
+		fmt.Fprintf(w, "%s  %s%s  %s\n", month.Format("Jan 2006"), row.String(),
+			strings.Repeat(" ", 31-utf8.RuneCountInString(row.String())), formatDurationShort(total))
+	}
+
+	fmt.Fprintf(w, "\n%s none  %s %s %s %s up to %s (busiest day)\n", heatmapLevels[0],
+		heatmapLevels[1], heatmapLevels[2], heatmapLevels[3], heatmapLevels[4], formatDurationShort(busiest))
+	fmt.Fprintf(w, "Total: %s\n", formatDuration(summary.TotalDuration))
+	return nil
+}
+
+// outputTimeline writes a chronological list of [service.Event]s in the configured report format.
+//
+// In table format, events are printed as a narrative worklog grouped by day, for example:
+//
+//	09:00  started @work: deploy
+//	10:30  paused (Manual)
+//	11:15  resumed
+//	12:00  stopped [2h 45m]
+//
+// JSON emits the events array, and CSV emits one row per event.
+//
+// Returns an error if writing the output fails.
+func outputTimeline(w io.Writer, events []service.Event) error {
+	switch reportFormat {
+	case "json":
+		if events == nil {
+			events = []service.Event{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(events)
+
+	case "csv":
+		writer := newReportCSVWriter(w)
+		defer writer.Flush()
+
+		if !reportNoHeader {
+			writer.Write([]string{"Time", "Event", "Entry ID", "Project", "Title", "Reason", "Duration (minutes)"})
+		}
+		for _, ev := range events {
+			duration := ""
+			if ev.Kind == service.EventStop {
+				duration = fmt.Sprintf("%.1f", ev.Duration.Minutes())
+			}
+			writer.Write([]string{
+				ev.Time.Format("2006-01-02 15:04:05"),
+				string(ev.Kind),
+				ev.EntryID,
+				ev.Project,
+				ev.Title,
+				ev.Reason,
+				duration,
+			})
+		}
+		return nil
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No entries found")
+		return nil
+	}
+
+	day := ""
+	for _, ev := range events {
+		if d := ev.Time.Format("2006-01-02 (Mon)"); d != day {
+			if day != "" {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintln(w, d)
+			day = d
+		}
+
+		fmt.Fprintf(w, "  %s  ", ev.Time.Format("15:04"))
+		switch ev.Kind {
+		case service.EventStart:
+			fmt.Fprintf(w, "started @%s", ev.Project)
+			if ev.Title != "" {
+				fmt.Fprintf(w, ": %s", ev.Title)
+			}
+		case service.EventPause:
+			fmt.Fprintf(w, "paused (%s)", ev.Reason)
+		case service.EventResume:
+			fmt.Fprint(w, "resumed")
+		case service.EventStop:
+			fmt.Fprintf(w, "stopped @%s [%s]", ev.Project, formatDurationShort(ev.Duration))
+		}
+		fmt.Fprintln(w)
 	}
 
 	return nil
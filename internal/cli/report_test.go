@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/model"
+)
+
+func TestEntryCSVRowNetDuration(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	resume := start.Add(time.Hour)
+	e := model.Entry{
+		ID:        "01HMENTRY",
+		Project:   &model.Project{Name: "work"},
+		StartTime: start,
+		EndTime:   &end,
+		Status:    model.StatusStopped,
+		Pauses:    []model.Pause{{PauseTime: start.Add(30 * time.Minute), ResumeTime: &resume}},
+	}
+
+	row := entryCSVRow(e, e.Duration(), end)
+	if gross := row[3]; gross != "120.0" {
+		t.Errorf("gross minutes = %s, want 120.0", gross)
+	}
+	if net := row[4]; net != "90.0" {
+		t.Errorf("net minutes = %s, want 90.0", net)
+	}
+}
+
+func TestOutputTableGolden(t *testing.T) {
+	plainOutput(t)
+
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	entry := func(id, project, title string, tags []string, start time.Time, d time.Duration) model.ReportEntry {
+		end := start.Add(d)
+		return model.ReportEntry{
+			Entry: model.Entry{
+				ID: id, Title: title, StartTime: start, EndTime: &end, Status: model.StatusStopped,
+			},
+			ProjectName: project,
+			TagNames:    tags,
+			Duration:    d,
+		}
+	}
+	summary := &model.ReportSummary{
+		Period:    "2024-01-15 to 2024-01-16",
+		StartDate: day,
+		EndDate:   day.AddDate(0, 0, 2),
+		Entries: []model.ReportEntry{
+			entry("01HMA0000000000000000000AA", "work", "Standup", []string{"meeting"}, day.Add(9*time.Hour), 15*time.Minute),
+			entry("01HMB0000000000000000000BB", "client", "Homepage redesign for the spring launch", []string{"design", "client"}, day.Add(10*time.Hour), 2*time.Hour+30*time.Minute),
+			entry("01HMC0000000000000000000CC", "work", "Code review", nil, day.Add(33*time.Hour), 45*time.Minute),
+			entry("01HMD0000000000000000000DD", "home", "Taxes", nil, day.Add(38*time.Hour), time.Hour),
+		},
+		TotalDuration: 4*time.Hour + 30*time.Minute,
+		ByProject: map[string]time.Duration{
+			"client": 2*time.Hour + 30*time.Minute,
+			"home":   time.Hour,
+			"work":   time.Hour,
+		},
+		ByDay: map[string]time.Duration{
+			"2024-01-15": 2*time.Hour + 45*time.Minute,
+			"2024-01-16": time.Hour + 45*time.Minute,
+		},
+		ByTag: map[string]time.Duration{
+			"client":  2*time.Hour + 30*time.Minute,
+			"design":  2*time.Hour + 30*time.Minute,
+			"meeting": 15 * time.Minute,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := outputTable(&buf, summary); err != nil {
+		t.Fatalf("outputTable() error = %v", err)
+	}
+	assertGolden(t, "report_table", buf.Bytes())
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// restartCmd stops the running timer, if any, and starts a new one in a single step.
+//
+// It behaves like [startCmd] with `start.auto_stop_previous` enabled: the previous entry ends at the same instant the
+// new one starts, so no time falls between them.
+var restartCmd = &cobra.Command{
+	Use:   "restart @project [\"title\"] [+tag]...",
+	Short: "Stop the current timer and start a new one",
+	Long: `Stop the running or paused timer, if any, and start a new one.
+
+Examples:
+  tally restart @work "Code review"
+  tally restart @work "Deploy" +ops`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRestart,
+}
+
+func runRestart(cmd *cobra.Command, args []string) error {
+	if _, _, _, err := parseStartArgs(args, ""); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	running, err := db.GetRunningEntry()
+	if err != nil {
+		return fmt.Errorf("failed to check running entry: %w", err)
+	}
+
+	return startTimer(args, running, "", true)
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"strings"
@@ -12,7 +11,10 @@ import (
 	"github.com/thinktide/tally/internal/model"
 )
 
-var resumeFrom string
+var (
+	resumeFrom     string
+	resumeDuration time.Duration
+)
 
 var resumeCmd = &cobra.Command{
 	Use:   "resume [@project]",
@@ -28,12 +30,22 @@ With @project, resumes the most recent task from that project:
   - Otherwise, clones the most recent entry for that project (same title
     and tags) into a new entry starting now (or at the -f time).
 
-Use -f to specify a custom start/resume time.`,
+Use -f to specify a custom start/resume time.
+
+With @project and --duration, logs a completed block instead of starting a
+timer: it ends now (or at -f plus the duration) and starts the given
+duration earlier. The running timer is left untouched.
+
+Examples:
+  tally resume                      # Resume paused timer or reopen last entry
+  tally resume @work                # Continue the last @work task
+  tally resume @work --duration 30m # Log a 30 minute @work block ending now`,
 	RunE: runResume,
 }
 
 func init() {
 	resumeCmd.Flags().StringVarP(&resumeFrom, "from", "f", "", "Resume start time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	resumeCmd.Flags().DurationVar(&resumeDuration, "duration", 0, "Log a completed block of this length for @project instead of starting a timer")
 }
 
 // parseResumeArgs extracts an optional @project from the arguments.
@@ -66,7 +78,24 @@ func runResume(cmd *cobra.Command, args []string) error {
 			return err
 		}
 	} else {
-		startTime = time.Now()
+		startTime = db.Now()
+	}
+
+	if resumeDuration != 0 {
+		if projectFilter == "" {
+			return fmt.Errorf("--duration requires @project")
+		}
+		if resumeDuration < 0 {
+			return fmt.Errorf("--duration must be positive")
+		}
+
+		end := db.Now()
+		start := end.Add(-resumeDuration)
+		if resumeFrom != "" {
+			start = startTime
+			end = start.Add(resumeDuration)
+		}
+		return logProjectBlock(projectFilter, start, end)
 	}
 
 	// If @project is specified, use project-specific resume logic
@@ -84,7 +113,12 @@ func resumeProject(projectName string, startTime time.Time) error {
 		return fmt.Errorf("failed to look up project: %w", err)
 	}
 	if project == nil {
-		return fmt.Errorf("no entries found for project @%s", projectName)
+		if project, err = suggestProject(os.Stdout, projectName); err != nil {
+			return err
+		}
+		if project == nil {
+			return fmt.Errorf("no entries found for project @%s", projectName)
+		}
 	}
 
 	// Stop any currently running/paused entry first
@@ -100,12 +134,7 @@ func resumeProject(projectName string, startTime time.Time) error {
 		if err != nil {
 			return fmt.Errorf("failed to reload stopped entry: %w", err)
 		}
-		duration := running.Duration()
-		fmt.Printf("Stopped timer for @%s", running.Project.Name)
-		if running.Title != "" {
-			fmt.Printf(": %s", running.Title)
-		}
-		fmt.Printf(" [%s]\n", formatDuration(duration))
+		printStopped(running)
 	}
 
 	// Check if the most recent overall entry is already from this project
@@ -125,7 +154,7 @@ func resumeProject(projectName string, startTime time.Time) error {
 		return fmt.Errorf("failed to get last entry for project: %w", err)
 	}
 	if projectEntry == nil {
-		return fmt.Errorf("no entries found for project @%s", projectName)
+		return fmt.Errorf("no entries found for project @%s", project.Name)
 	}
 
 	// Clone: create a new entry with the same title and tags
@@ -139,7 +168,7 @@ func resumeProject(projectName string, startTime time.Time) error {
 		return fmt.Errorf("failed to create entry: %w", err)
 	}
 
-	fmt.Printf("Resumed @%s", projectName)
+	fmt.Printf("Resumed @%s", project.Name)
 	if newEntry.Title != "" {
 		fmt.Printf(": %s", newEntry.Title)
 	}
@@ -150,6 +179,67 @@ func resumeProject(projectName string, startTime time.Time) error {
 	return nil
 }
 
+// logProjectBlock clones the most recent entry for projectName as a completed entry spanning start to end.
+//
+// The title and tags are copied from the project's last entry. The block is refused if it overlaps any stopped entry,
+// in which case the overlapping entries are listed. Running and paused entries are not counted, so a block can be
+// logged while a timer runs, as the help for --duration describes.
+//
+// Returns an error if the project has no entries, the block overlaps existing entries, or a database operation fails.
+func logProjectBlock(projectName string, start, end time.Time) error {
+	project, err := db.GetProjectByName(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to look up project: %w", err)
+	}
+	if project == nil {
+		return fmt.Errorf("no entries found for project @%s", projectName)
+	}
+
+	projectEntry, err := db.GetLastEntryForProject(project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get last entry for project: %w", err)
+	}
+	if projectEntry == nil {
+		return fmt.Errorf("no entries found for project @%s", projectName)
+	}
+
+	candidates, err := db.EntriesOverlapping(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to check for overlapping entries: %w", err)
+	}
+	var overlapping []model.Entry
+	for _, e := range candidates {
+		if e.EndTime != nil {
+			overlapping = append(overlapping, e)
+		}
+	}
+	if len(overlapping) > 0 {
+		fmt.Printf("The block %s - %s overlaps existing entries:\n\n", start.Format("15:04"), end.Format("15:04"))
+		printEntriesTable(os.Stdout, overlapping)
+		return fmt.Errorf("refusing to create overlapping entry")
+	}
+
+	tagIDs := make([]string, len(projectEntry.Tags))
+	for i, t := range projectEntry.Tags {
+		tagIDs[i] = t.ID
+	}
+
+	newEntry, err := db.CreateCompletedEntry(project.ID, projectEntry.Title, tagIDs, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to create entry: %w", err)
+	}
+
+	fmt.Printf("Logged @%s", projectName)
+	if newEntry.Title != "" {
+		fmt.Printf(": %s", newEntry.Title)
+	}
+	if len(newEntry.Tags) > 0 {
+		fmt.Printf(" %s", formatTagsFromModel(newEntry.Tags))
+	}
+	fmt.Printf(" [%s - %s, %s]\n", start.Format("15:04"), end.Format("15:04"), formatDuration(newEntry.Duration()))
+	return nil
+}
+
 func reopenEntry(entry *model.Entry, startTime time.Time) error {
 	if entry.Status != model.StatusStopped {
 		fmt.Println("No timer to resume")
@@ -173,22 +263,18 @@ func reopenEntry(entry *model.Entry, startTime time.Time) error {
 	}
 	fmt.Println()
 
-	fmt.Print("Reopen this entry? A pause will be created for the gap. [y/N]: ")
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	ok, err := confirm("Reopen this entry? A pause will be created for the gap.", false)
 	if err != nil {
 		return err
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
-
-	if input != "y" && input != "yes" {
+	if !ok {
 		fmt.Println("Cancelled")
 		return nil
 	}
 
 	// Create pause for the gap
 	if entry.EndTime != nil {
-		_, err = db.CreatePause(entry.ID, *entry.EndTime, &startTime, "Manual")
+		_, err = db.CreatePause(entry.ID, *entry.EndTime, &startTime, defaultPauseReason)
 		if err != nil {
 			return fmt.Errorf("failed to create pause: %w", err)
 		}
@@ -217,7 +303,7 @@ func resumeDefault(startTime time.Time) error {
 	if entry != nil {
 		if entry.Status == model.StatusRunning {
 			fmt.Println("Timer is already running")
-			printStatus(entry)
+			printStatus(os.Stdout, entry)
 			return nil
 		}
 
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+func TestLogProjectBlockWhileTimerRuns(t *testing.T) {
+	openTestDB(t)
+	now := time.Now().Truncate(time.Minute)
+
+	project, err := db.GetOrCreateProject("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CreateCompletedEntry(project.ID, "Review", nil, now.Add(-5*time.Hour), now.Add(-4*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	other, err := db.GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	running, err := db.CreateEntryAt(other.ID, "Task", nil, now.Add(-2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The block falls inside the running timer, which is left alone
+	captureOutput(t, func() { err = logProjectBlock("x", now.Add(-30*time.Minute), now) })
+	if err != nil {
+		t.Fatalf("logging a block while a timer runs: %v", err)
+	}
+	if e, err := db.GetEntryByID(running.ID); err != nil || e.Status != model.StatusRunning {
+		t.Errorf("running timer = %+v, %v, want it still running", e, err)
+	}
+
+	// Stopped entries are still protected
+	captureOutput(t, func() { err = logProjectBlock("x", now.Add(-45*time.Minute), now.Add(-15*time.Minute)) })
+	if err == nil {
+		t.Error("logging a block over a stopped entry succeeded, want it refused")
+	}
+}
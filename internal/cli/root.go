@@ -1,15 +1,64 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/color"
+	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/idle"
+	"github.com/thinktide/tally/internal/sleep"
 )
 
 // Version indicates the current build version of the application. Defaults to "dev" if not explicitly set.
-var Version = "dev"
+//
+// Commit is the git commit the binary was built from, and BuildDate the time it was built. Like Version, both are set
+// with -ldflags at build time and default to "unknown".
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// versionJSON specifies whether [versionCmd] prints build metadata as JSON instead of the plain version string.
+var versionJSON bool
+
+// versionInfo is the JSON shape of `tally version --json`.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// noSleepCheck disables the automatic sleep check for a single invocation.
+//
+// dataDir selects the data directory for a single invocation, overriding TALLY_DATA_DIR and data.location.
+//
+// colorMode selects when table and status output is colored: "auto" (default), "always", or "never".
+var (
+	noSleepCheck bool
+	dataDir      string
+	colorMode    string
+)
+
+// sleepCheckCommands lists the commands that read or change the running timer's duration, and so record sleep and
+// idle time as pauses first (see [checkSleep] and [checkIdle]).
+var sleepCheckCommands = map[string]bool{
+	"status": true,
+	"stop":   true,
+	"pause":  true,
+	"report": true,
+	"today":  true,
+}
 
 // rootCmd is the primary command for the CLI, serving as the entry point for all subcommands.
 //
@@ -20,15 +69,51 @@ var rootCmd = &cobra.Command{
 	Short: "A CLI time tracking utility",
 	Long:  `Tally is a command-line time tracking utility that helps you track time spent on projects.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip DB init for version command
-		if cmd.Name() == "version" {
+		if err := color.Setup(colorMode); err != nil {
+			return err
+		}
+
+		// Skip DB init for version command and completion script generation
+		if cmd.Name() == "version" || cmd.Name() == "completion" {
 			return nil
 		}
 
+		// --data-dir takes precedence over TALLY_DATA_DIR, which takes precedence over data.location
+		db.DataDirOverride = dataDir
+		if db.DataDirOverride == "" {
+			db.DataDirOverride = os.Getenv("TALLY_DATA_DIR")
+		}
+
 		if err := db.Init(); err != nil {
+			// Dynamic completion works without a database; it just offers no names
+			if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+				db.Close()
+				db.DB = nil
+				return nil
+			}
 			return fmt.Errorf("failed to initialize database: %w", err)
 		}
 
+		// Times are read, entered, and bucketed into periods in the configured zone. An unknown zone fails every
+		// command except config, which is how it is fixed.
+		loc, err := config.Location()
+		if err != nil && cmd.Parent() != configCmd {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if err == nil {
+			db.Location = loc
+		}
+
+		if sleepCheckCommands[cmd.Name()] {
+			checkSleep()
+			checkIdle()
+		}
+		// Completion runs on every tab press and must not change data
+		if cmd.Name() != cobra.ShellCompRequestCmd && cmd.Name() != cobra.ShellCompNoDescRequestCmd {
+			stopOverdueEntries()
+		}
+
 		return nil
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
@@ -54,29 +139,170 @@ func Execute() {
 // [startCmd], [stopCmd], [statusCmd], [pauseCmd], [resumeCmd], [logCmd], [editCmd], [deleteCmd], [reportCmd], and [configCmd],
 // enabling the CLI's functionality. It ensures all commands are integrated with the application's root command.
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&noSleepCheck, "no-sleep-check", false, "Don't record system sleep as pauses before this command")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", color.Auto, "Color output: auto, always, never (auto respects NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", "", "Data directory to use (overrides TALLY_DATA_DIR and data.location)")
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Print version, commit, build date, Go version, and platform as JSON")
+
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(restartCmd)
+	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(currentCmd)
+	rootCmd.AddCommand(todayCmd)
 	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(pausesCmd)
 	rootCmd.AddCommand(resumeCmd)
+	rootCmd.AddCommand(continueCmd)
+	rootCmd.AddCommand(duplicateCmd)
+	rootCmd.AddCommand(breakCmd)
+	rootCmd.AddCommand(backCmd)
 	rootCmd.AddCommand(logCmd)
 	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(pingCmd)
+	rootCmd.AddCommand(splitCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(billCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(projectsCmd)
+	rootCmd.AddCommand(tagsCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(aliasCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+	rootCmd.AddCommand(gapsCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(completionCmd)
+}
+
+// checkSleep records sleep that happened while the timer was running as pauses, using [sleep.CheckAndHandleSleep],
+// unless --no-sleep-check is given or [config.KeySleepDetection] is disabled.
+//
+// The check never fails the command: platforms without sleep detection are skipped silently, and other errors, such
+// as an unreadable power log, are printed as warnings. Recorded pauses are reported on stderr so JSON output stays
+// clean.
+func checkSleep() {
+	if noSleepCheck {
+		return
+	}
+	enabled, err := config.GetBool(config.KeySleepDetection)
+	if err != nil || !enabled {
+		return
+	}
+
+	periods, err := sleep.CheckAndHandleSleep()
+	for _, p := range periods {
+		fmt.Fprintf(os.Stderr, "Recorded %s as a pause: %s - %s (%s)\n",
+			strings.ToLower(p.Reason), p.Start.In(db.Location).Format("15:04:05"), p.End.In(db.Location).Format("15:04:05"),
+			formatDuration(p.Duration()))
+	}
+	if err != nil && !errors.Is(err, sleep.ErrUnsupported) {
+		fmt.Fprintf(os.Stderr, "Warning: sleep check failed: %v (disable with --no-sleep-check or 'tally config set %s false')\n",
+			err, config.KeySleepDetection)
+	}
+}
+
+// checkIdle records idle time on the running timers as pauses, using [idle.CheckAndHandleIdle], when `idle.threshold`
+// is set.
+//
+// Like [checkSleep], the check never fails the command: recorded pauses are reported on stderr so JSON output stays
+// clean, and errors are printed as warnings. The last activity time is left unchanged; only `tally ping` updates it.
+func checkIdle() {
+	periods, err := idle.CheckAndHandleIdle()
+	for _, p := range periods {
+		fmt.Fprintf(os.Stderr, "Recorded idle pause on @%s: %s - %s (%s)\n", p.Project,
+			p.Start.Format("15:04:05"), p.End.Format("15:04:05"), formatDuration(p.Duration()))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: idle check failed: %v\n", err)
+	}
+}
+
+// stopOverdueEntries stops the running or paused entries whose planned end, set with `tally start --until`, has
+// passed, at their planned end. Since tally does not run in the background, this is how a planned end takes effect: on
+// the first command after it.
+//
+// An entry paused or resumed after its planned end is stopped at its latest pause or resume instead, so no pause is
+// left outside it. Like [checkSleep], this never fails the command: stopped entries are reported on stderr, and
+// errors are printed as warnings.
+func stopOverdueEntries() {
+	now := db.Now()
+	entries, err := db.ListOverdueEntries(now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to check planned ends: %v\n", err)
+		return
+	}
+
+	for _, e := range entries {
+		at := *e.PlannedEnd
+		if at.Before(e.StartTime) {
+			at = e.StartTime
+		}
+		for _, p := range e.Pauses {
+			latest := p.PauseTime
+			if p.ResumeTime != nil {
+				latest = *p.ResumeTime
+			}
+			if latest.After(at) {
+				at = latest
+			}
+		}
+
+		if err := db.StopEntryAt(e.ID, at); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to stop @%s at its planned end: %v\n", e.Project.Name, err)
+			continue
+		}
+		stopped, err := db.GetEntryByID(e.ID)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Stopped timer for @%s", stopped.Project.Name)
+		if stopped.Title != "" {
+			fmt.Fprintf(os.Stderr, ": %s", stopped.Title)
+		}
+		fmt.Fprintf(os.Stderr, " at %s as planned [%s]\n", at.Format("2006-01-02 15:04"), formatDuration(stopped.Duration()))
+	}
 }
 
 // versionCmd represents the command to print the application's version number.
 //
 // When executed, this command outputs the current version of the application. The version is stored in the [Version] variable.
+// With --json, it prints the version along with [Commit], [BuildDate], the Go version, and the OS and architecture.
 //
 // This command does not require initialization of other subsystems like the database, ensuring quick response time. It is useful for verifying
 // the installed version or debugging issues related to versioning.
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("tally %s\n", Version)
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !versionJSON {
+			fmt.Printf("tally %s\n", Version)
+			return nil
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(versionInfo{
+			Version:   Version,
+			Commit:    Commit,
+			BuildDate: BuildDate,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		})
 	},
 }
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+)
+
+// captureOutput runs fn with os.Stdout and os.Stderr redirected, and returns what was written to each.
+func captureOutput(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+	read := func(r *os.File, dst *string, done chan<- struct{}) {
+		b, _ := io.ReadAll(r)
+		*dst = string(b)
+		close(done)
+	}
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDone, errDone := make(chan struct{}), make(chan struct{})
+	go read(outR, &stdout, outDone)
+	go read(errR, &stderr, errDone)
+
+	origOut, origErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	defer func() {
+		os.Stdout, os.Stderr = origOut, origErr
+		outW.Close()
+		errW.Close()
+		<-outDone
+		<-errDone
+	}()
+	fn()
+	return
+}
+
+// fakeJournalctl puts a journalctl on PATH that reports a single suspend from start to end.
+func fakeJournalctl(t *testing.T, start, end time.Time) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat <<'EOF'\n" +
+		start.UTC().Format(time.RFC3339) + " laptop systemd-sleep[801]: Entering sleep state 'suspend'...\n" +
+		end.UTC().Format(time.RFC3339) + " laptop systemd-sleep[801]: System returned from sleep state.\n" +
+		"EOF\n"
+	if err := os.WriteFile(filepath.Join(dir, "journalctl"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestSleepCheckKeepsJSONOutputClean(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("sleep detection is faked with journalctl, which is only used on Linux")
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"status", []string{"status", "--json"}},
+		{"report", []string{"report", "today", "--format", "json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A timer running for two hours, with the machine asleep for half an hour of it
+			dir := t.TempDir()
+			db.DataDirOverride = dir
+			if err := db.Init(); err != nil {
+				t.Fatal(err)
+			}
+			project, err := db.GetOrCreateProject("work")
+			if err != nil {
+				t.Fatal(err)
+			}
+			now := time.Now()
+			if _, err := db.CreateEntryAt(project.ID, "Task", nil, now.Add(-2*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+			db.Close()
+			fakeJournalctl(t, now.Add(-time.Hour), now.Add(-30*time.Minute))
+			t.Cleanup(func() {
+				db.DB = nil
+				db.DataDirOverride, dataDir = "", ""
+				statusJSON, reportFormat = false, ""
+				rootCmd.SetArgs(nil)
+			})
+
+			rootCmd.SetArgs(append(tt.args, "--data-dir", dir, "--color", "never"))
+			var execErr error
+			stdout, stderr := captureOutput(t, func() { execErr = rootCmd.Execute() })
+			if execErr != nil {
+				t.Fatalf("%s: %v\nstderr: %s", strings.Join(tt.args, " "), execErr, stderr)
+			}
+
+			var out map[string]any
+			if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+				t.Errorf("stdout is not valid JSON: %v\n%s", err, stdout)
+			}
+			if !strings.Contains(stderr, "Recorded system suspend as a pause") {
+				t.Errorf("stderr = %q, want the recorded sleep notice", stderr)
+			}
+		})
+	}
+}
+
+func TestTimezoneSetting(t *testing.T) {
+	systemZone := time.Local
+	t.Cleanup(func() {
+		db.Close()
+		db.DB = nil
+		db.DataDirOverride, dataDir = "", ""
+		db.Location = systemZone
+		statusJSON = false
+		rootCmd.SetArgs(nil)
+	})
+	run := func() error {
+		rootCmd.SetArgs([]string{"status", "--json", "--data-dir", t.TempDir(), "--no-sleep-check", "--color", "never"})
+		var err error
+		captureOutput(t, func() { err = rootCmd.Execute() })
+		return err
+	}
+
+	t.Setenv("TALLY_TIMEZONE", "Asia/Tokyo")
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	if db.Location.String() != "Asia/Tokyo" {
+		t.Errorf("db.Location = %s, want Asia/Tokyo", db.Location)
+	}
+	if time.Local != systemZone {
+		t.Errorf("time.Local changed to %s", time.Local)
+	}
+
+	t.Setenv("TALLY_TIMEZONE", "Mars/Olympus_Mons")
+	err := run()
+	if err == nil || !strings.Contains(err.Error(), "TALLY_TIMEZONE") {
+		t.Errorf("running with an unknown TALLY_TIMEZONE returned %v, want an error naming it", err)
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// showFormat defines the output format of [showCmd]. Accepts "table" (default) or "json".
+var showFormat string
+
+// showCmd displays a single time entry, including its project, tags, and pauses.
+//
+// The entry can be identified by its full ID or by any unique prefix of it. This is the read-only counterpart to
+// [editCmd], useful for inspecting exactly what is stored without opening an editor.
+var showCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a time entry",
+	Long: `Show a single time entry with its tags and pauses.
+
+The ID may be shortened to any unique prefix.
+
+Examples:
+  tally show 01JQXYZ123              # Human-readable details
+  tally show 01JQ --format json      # Full entry as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+// init configures flags for the [showCmd] command.
+func init() {
+	showCmd.Flags().StringVar(&showFormat, "format", "table", "Output format: table, json")
+}
+
+// runShow resolves the entry ID given in args and prints the entry in the requested format.
+//
+// Returns an error if the ID cannot be resolved, the entry cannot be loaded, or the format is unknown.
+func runShow(cmd *cobra.Command, args []string) error {
+	entryID, err := db.ResolveEntryID(args[0])
+	if err != nil {
+		return err
+	}
+
+	entry, err := db.GetEntryByID(entryID)
+	if err != nil {
+		return fmt.Errorf("entry not found: %w", err)
+	}
+
+	switch showFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entry)
+	case "table":
+		printEntryDetails(entry)
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", showFormat)
+	}
+}
+
+// printEntryDetails prints all stored details of an entry, one field per line, followed by its pauses.
+func printEntryDetails(entry *model.Entry) {
+	fmt.Printf("Entry: %s\n", entry.ID)
+	fmt.Printf("  Project:  @%s\n", entry.Project.Name)
+	if entry.Title != "" {
+		fmt.Printf("  Title:    %s\n", entry.Title)
+	}
+	if entry.Note != "" {
+		fmt.Printf("  Note:     %s\n", entry.Note)
+	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf("  Tags:     %s\n", formatTagsFromModel(entry.Tags))
+	}
+	if entry.Timer != "" {
+		fmt.Printf("  Timer:    %s\n", entry.Timer)
+	}
+	fmt.Printf("  Status:   %s\n", entry.Status)
+	if !entry.Billable {
+		fmt.Println("  Billable: no")
+	}
+	if entry.BilledAt != nil {
+		fmt.Printf("  Billed:   %s\n", entry.BilledAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("  Started:  %s\n", entry.StartTime.Format("2006-01-02 15:04:05"))
+	if entry.EndTime != nil {
+		fmt.Printf("  Stopped:  %s\n", entry.EndTime.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("  Duration: %s\n", formatDuration(entry.Duration()))
+	if entry.Estimate != nil {
+		fmt.Printf("  Estimate: %s (%s)\n", formatDuration(*entry.Estimate), formatDelta(entry.Duration()-*entry.Estimate))
+	}
+
+	if len(entry.Pauses) > 0 {
+		fmt.Println("  Pauses:")
+		for _, p := range entry.Pauses {
+			resume := "now"
+			if p.ResumeTime != nil {
+				resume = p.ResumeTime.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("    %s - %s (%s, %s)\n",
+				p.PauseTime.Format("2006-01-02 15:04:05"), resume, formatDuration(p.Duration()), p.Reason)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// splitCmd divides a stopped entry into two entries at a given time.
+//
+// Both halves keep the project, title, and tags, so either can then be edited into a separate task.
+var splitCmd = &cobra.Command{
+	Use:   "split <id> <time>",
+	Short: "Split a stopped entry into two at a given time",
+	Long: `Split a stopped entry into two entries at the given time.
+
+The first entry keeps the original start and ends at the split time; the
+second starts at the split time and keeps the original end. Tags are copied
+to both, and pauses go to the half that contains them.
+
+The ID may be shortened to any unique prefix. The time accepts HH:MM,
+HH:MM:SS, or YYYY-MM-DD HH:MM:SS.
+
+Examples:
+  tally split 01JQXYZ123 14:00
+  tally split 01JQ "2024-01-01 14:00"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSplit,
+}
+
+func runSplit(cmd *cobra.Command, args []string) error {
+	at, err := parseTimeInput(args[1])
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	entryID, err := db.ResolveEntryID(args[0])
+	if err != nil {
+		return err
+	}
+
+	second, err := db.SplitEntry(entryID, at)
+	if err != nil {
+		return fmt.Errorf("failed to split entry: %w", err)
+	}
+
+	first, err := db.GetEntryByID(entryID)
+	if err != nil {
+		return fmt.Errorf("failed to reload entry: %w", err)
+	}
+
+	fmt.Printf("Split @%s", first.Project.Name)
+	if first.Title != "" {
+		fmt.Printf(": %s", first.Title)
+	}
+	fmt.Printf(" at %s\n\n", at.Format("2006-01-02 15:04:05"))
+	printEntriesTable(os.Stdout, []model.Entry{*first, *second})
+	return nil
+}
@@ -2,13 +2,39 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
 	"github.com/thinktide/tally/internal/model"
 )
 
+// startForce specifies whether [startCmd] stops a running or paused timer instead of refusing to start.
+//
+// startTimerName names the timer to start the entry on, so several timers can run at once. Empty is the default timer.
+//
+// startBillable specifies whether the new entry is billable.
+//
+// startMessage sets the title explicitly, so it may start with "@" or "+" and needs no other arguments to be parsed.
+//
+// startTemplate names a template saved with 'tally template save' to take the project, title, and tags from.
+//
+// startEstimate is how long the entry is expected to take, e.g. "2h", to compare with the time spent in reports.
+//
+// startUntil is the time the entry is planned to stop at, e.g. "17:00" (see [stopOverdueEntries]).
+var (
+	startForce     bool
+	startTimerName string
+	startBillable  bool
+	startMessage   string
+	startTemplate  string
+	startEstimate  string
+	startUntil     string
+)
+
 // startCmd initializes the "start" command for creating a new time entry for a specific project.
 //
 // This command allows users to start a time entry with the specified project, optional title, and tags.
@@ -17,7 +43,8 @@ import (
 //   - Subsequent arguments can include an optional title in quotes and one or more tags prefixed with "+".
 //
 // The command ensures that:
-//   - Only one timer can run at a time.
+//   - Only one entry can run on a timer at a time. If `start.auto_stop_previous` is enabled or --force is given, the
+//     running or paused entry on the same timer is stopped first. Entries on other named timers keep running.
 //   - A new project or tag is created automatically if it does not exist.
 //
 // Returns an error if the provided arguments are invalid, or if there is an issue creating the time entry.
@@ -30,14 +57,40 @@ Examples:
   tally start @work
   tally start @work "Fixing bugs"
   tally start @work "Fixing bugs" +backend +urgent
-  tally start @personal +coding`,
-	Args: cobra.MinimumNArgs(1),
+  tally start @personal +coding
+  tally start @work --force      # Stop a running or paused timer first
+  tally start @work --timer deep # Run alongside the default timer
+  tally start @work "1:1" --billable=false   # Non-billable time
+  tally start @work -m "+1 for the new API" +review   # Title with a leading +
+  tally start --template morning # Project, title, and tags of a saved template
+  tally start @work "Refactor" --estimate 2h   # Compare with the time spent in reports
+  tally start @work "Focus" --until 17:00      # Stop at 17:00 (on the next tally command)`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if startTemplate != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("--template cannot be combined with @project, title, or tag arguments")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runStart,
 }
 
+func init() {
+	startCmd.Flags().BoolVar(&startForce, "force", false, "Stop a running or paused timer and start the new one")
+	startCmd.Flags().StringVar(&startTimerName, "timer", "", "Named timer to start, so several can run at once")
+	startCmd.Flags().BoolVar(&startBillable, "billable", true, "Mark the entry as billable (--billable=false for non-billable work)")
+	startCmd.Flags().StringVarP(&startMessage, "message", "m", "", "Title of the entry, instead of a positional title")
+	startCmd.Flags().StringVar(&startTemplate, "template", "", "Start from a saved template (see 'tally template')")
+	startCmd.Flags().StringVar(&startEstimate, "estimate", "", "Expected duration of the entry, e.g. 2h or 45m")
+	startCmd.Flags().StringVar(&startUntil, "until", "", "Stop the timer at this time (HH:MM or YYYY-MM-DD HH:MM:SS), applied by the next tally command")
+	startCmd.RegisterFlagCompletionFunc("template", completeTemplateName)
+}
+
 // runStart initializes and starts a new time entry for a specified project. It validates if an entry is already running.
 //
-// If there is an ongoing entry, it prints its status and exits. Otherwise, it processes the input arguments to extract the project name,
+// If there is an ongoing entry, it prints its status and exits, unless auto-stop or --force applies. Otherwise, it processes the input arguments to extract the project name,
 // title, and associated tags. The project and tags are retrieved or created if they do not already exist, and a new entry is created.
 //
 // - cmd: The current [cobra.Command] being executed.
@@ -48,21 +101,116 @@ Examples:
 //   - Parsing the arguments fails.
 //   - A database or application-level failure occurs during entry creation.
 //
+// With --template, the project, title, and tags come from the saved template instead (see [expandTemplate]).
+//
 // If successful, details of the started timer are printed to the console.
 func runStart(cmd *cobra.Command, args []string) error {
-	// Check if there's already a running entry
-	running, err := db.GetRunningEntry()
+	if startTemplate != "" {
+		cmd.SilenceUsage = true
+		templateArgs, title, err := expandTemplate(startTemplate)
+		if err != nil {
+			return err
+		}
+		args = templateArgs
+		// An explicit -m replaces the template's title
+		if startMessage == "" {
+			startMessage = title
+		}
+	}
+
+	// Check if there's already a running entry on this timer
+	running, err := db.GetRunningEntryForTimer(startTimerName)
 	if err != nil {
 		return fmt.Errorf("failed to check running entry: %w", err)
 	}
 	if running != nil {
-		fmt.Println("Timer already running:")
-		printStatus(running)
-		return nil
+		long, err := warnLongSession(running)
+		if err != nil {
+			return err
+		}
+		if long {
+			stopped, err := offerStopAtLastActivity(running)
+			if err != nil {
+				return err
+			}
+			if stopped {
+				running = nil
+			}
+		}
+	}
+	if running != nil && !startForce {
+		autoStop, err := config.GetBool(config.KeyStartAutoStopPrevious)
+		if err != nil {
+			return err
+		}
+		if !autoStop {
+			if running.Status == model.StatusPaused {
+				fmt.Println("A timer is paused (use 'tally resume' to continue it, or --force to stop it and start a new one):")
+			} else {
+				fmt.Println("Timer already running (use --force to stop it and start a new one):")
+			}
+			printStatus(os.Stdout, running)
+			return nil
+		}
+	}
+
+	return startTimer(args, running, startTimerName, startBillable)
+}
+
+// offerStopAtLastActivity asks whether to stop an over-long entry at the last activity recorded by `tally ping`.
+//
+// Nothing is asked when stdin is not a terminal, no activity was recorded while the entry was active, or stopping
+// there would not be valid (see [validateStopTime]).
+//
+// Returns whether the entry was stopped, or an error if reading the answer or stopping the entry fails.
+func offerStopAtLastActivity(entry *model.Entry) (bool, error) {
+	if !isInteractive() {
+		return false, nil
+	}
+	last, err := db.GetLastActivity()
+	if err != nil {
+		return false, fmt.Errorf("failed to get last activity: %w", err)
+	}
+	if last == nil || validateStopTime(entry, *last) != nil {
+		return false, nil
+	}
+
+	ok, err := confirm(fmt.Sprintf("Stop it at your last activity (%s)?", last.Format("2006-01-02 15:04")), false)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := db.StopEntryAt(entry.ID, *last); err != nil {
+		return false, fmt.Errorf("failed to stop entry: %w", err)
+	}
+	stopped, err := db.GetEntryByID(entry.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to reload stopped entry: %w", err)
 	}
+	printStopped(stopped)
+	return true, nil
+}
 
+// startTimer starts a new entry from start-style args, creating its project and tags if needed.
+//
+// If previous is not nil, it is stopped at the same instant the new entry starts, using [db.SwitchEntry], and its
+// summary is printed first; the new entry then runs on the previous entry's timer. Otherwise it runs on timer. The
+// new entry is marked non-billable unless billable is set, and is given the estimate in startEstimate and the planned
+// end in startUntil, if any.
+//
+// If the project is archived, the user is asked to unarchive it first; declining cancels the start.
+//
+// Returns an error if the arguments are invalid, or if a project, tag, or the entry cannot be created.
+func startTimer(args []string, previous *model.Entry, timer string, billable bool) error {
 	// Parse arguments
-	projectName, title, tagNames, err := parseStartArgs(args)
+	projectName, title, tagNames, err := parseStartArgs(args, startMessage)
+	if err != nil {
+		return err
+	}
+	estimate, err := parseEstimate(startEstimate)
+	if err != nil {
+		return err
+	}
+	plannedEnd, err := parseUntil(startUntil)
 	if err != nil {
 		return err
 	}
@@ -72,6 +220,20 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get/create project: %w", err)
 	}
+	if project.Archived {
+		ok, err := confirm(fmt.Sprintf("Project @%s is archived. Unarchive it?", project.Name), true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+		if err := db.SetProjectArchived(project.ID, false); err != nil {
+			return fmt.Errorf("failed to unarchive project: %w", err)
+		}
+		project.Archived = false
+	}
 
 	// Get or create tags
 	var tagIDs []string
@@ -83,12 +245,44 @@ func runStart(cmd *cobra.Command, args []string) error {
 		tagIDs = append(tagIDs, tag.ID)
 	}
 
-	// Create entry
-	entry, err := db.CreateEntry(project.ID, title, tagIDs)
-	if err != nil {
-		return fmt.Errorf("failed to create entry: %w", err)
+	// Create entry, stopping the previous one in the same transaction
+	var entry *model.Entry
+	if previous != nil {
+		entry, err = db.SwitchEntry(previous.ID, project.ID, title, tagIDs)
+		if err != nil {
+			return fmt.Errorf("failed to switch entry: %w", err)
+		}
+
+		stopped, err := db.GetEntryByID(previous.ID)
+		if err != nil {
+			return fmt.Errorf("failed to reload stopped entry: %w", err)
+		}
+		printStopped(stopped)
+	} else {
+		entry, err = db.CreateTimerEntry(project.ID, title, tagIDs, timer)
+		if err != nil {
+			return fmt.Errorf("failed to create entry: %w", err)
+		}
 	}
 
+	if !billable {
+		if err := db.SetEntryBillable(entry.ID, false); err != nil {
+			return fmt.Errorf("failed to mark entry non-billable: %w", err)
+		}
+		entry.Billable = false
+	}
+	if estimate != nil {
+		if err := db.SetEntryEstimate(entry.ID, estimate); err != nil {
+			return fmt.Errorf("failed to set estimate: %w", err)
+		}
+		entry.Estimate = estimate
+	}
+	if plannedEnd != nil {
+		if err := db.SetEntryPlannedEnd(entry.ID, plannedEnd); err != nil {
+			return fmt.Errorf("failed to set planned end: %w", err)
+		}
+		entry.PlannedEnd = plannedEnd
+	}
 	entry.Project = project
 
 	fmt.Printf("Started timer for @%s", project.Name)
@@ -98,6 +292,18 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if len(tagNames) > 0 {
 		fmt.Printf(" [%s]", formatTags(tagNames))
 	}
+	if entry.Timer != "" {
+		fmt.Printf(" on timer '%s'", entry.Timer)
+	}
+	if !entry.Billable {
+		fmt.Print(" (non-billable)")
+	}
+	if entry.Estimate != nil {
+		fmt.Printf(" (estimate %s)", formatDurationShort(*entry.Estimate))
+	}
+	if entry.PlannedEnd != nil {
+		fmt.Printf(" (until %s)", formatPlannedEnd(*entry.PlannedEnd))
+	}
 	fmt.Println()
 
 	return nil
@@ -112,15 +318,20 @@ func runStart(cmd *cobra.Command, args []string) error {
 //
 // If multiple projects are specified, it returns an error. A project name is required.
 //
+// When message is not empty, it is used as the title as is, like git's -m, and positional title words are rejected.
+//
 // args:
 //   - An array of strings representing the command-line arguments.
 //
+// message:
+//   - The title given with the -m/--message flag, or an empty string.
+//
 // Returns:
 //   - project: The project name extracted from the arguments.
-//   - title: The constructed title from remaining positional arguments, if any.
+//   - title: message, or the constructed title from remaining positional arguments, if any.
 //   - tags: A slice of tag names extracted from the arguments.
 //   - err: An error if validation fails, such as missing project or duplicate project.
-func parseStartArgs(args []string) (project, title string, tags []string, err error) {
+func parseStartArgs(args []string, message string) (project, title string, tags []string, err error) {
 	for _, arg := range args {
 		if strings.HasPrefix(arg, "@") {
 			if project != "" {
@@ -145,9 +356,57 @@ func parseStartArgs(args []string) (project, title string, tags []string, err er
 		return
 	}
 
+	if message != "" {
+		if title != "" {
+			err = fmt.Errorf("title given both as an argument and with -m (%q)", title)
+			return
+		}
+		title = message
+	}
+
 	return
 }
 
+// parseUntil parses the planned end of a new entry with [parseTimeInput]. An empty value means no planned end and is
+// returned as nil.
+//
+// Returns an error if the value cannot be parsed or is not in the future.
+func parseUntil(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := parseTimeInput(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --until: %w", err)
+	}
+	if !t.After(db.Now()) {
+		return nil, fmt.Errorf("--until must be in the future: %s", t.Format("2006-01-02 15:04:05"))
+	}
+	return &t, nil
+}
+
+// formatPlannedEnd formats a planned end as "15:04", with the date as well unless it is today.
+func formatPlannedEnd(t time.Time) string {
+	if t.Format("2006-01-02") == db.Now().Format("2006-01-02") {
+		return t.Format("15:04")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// parseEstimate parses an estimate such as "2h" or "1h30m". An empty value means no estimate and is returned as nil.
+//
+// Returns an error if the value is not a positive duration.
+func parseEstimate(value string) (*time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return nil, fmt.Errorf("invalid estimate: %s (use a duration like 2h or 45m)", value)
+	}
+	return &d, nil
+}
+
 // formatTags formats a slice of tags as a single string with each tag prefixed by a "+" and separated by a space.
 //
 // tags is a slice of strings representing individual tag names.
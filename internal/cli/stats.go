@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/config"
+	"github.com/thinktide/tally/internal/service"
+)
+
+// statsFormat specifies the output format for [statsCmd]: "table" or "json". Defaults to the output.format setting.
+var statsFormat string
+
+// statsCmd prints productivity statistics for a period, or for all entries when no period is given.
+//
+// Running and paused entries count toward the totals, but not toward the average or longest session since they have
+// no final duration yet.
+var statsCmd = &cobra.Command{
+	Use:   "stats [period]",
+	Short: "Show productivity statistics",
+	Long: `Show total tracked time, session lengths, most used project and tag, and a
+per-weekday breakdown. Without a period, all entries are included.
+
+Periods:
+  today, yesterday, week, lastWeek, month, lastMonth, year, lastYear
+
+Examples:
+  tally stats                 # All time
+  tally stats month           # This month
+  tally stats --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+// init configures flags for the [statsCmd] command.
+func init() {
+	statsCmd.Flags().StringVar(&statsFormat, "format", "", "Output format: table, json")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if statsFormat == "" {
+		format, err := config.Get(config.KeyOutputFormat)
+		if err != nil {
+			return err
+		}
+		statsFormat = format
+		// Report-only formats such as csv fall back to the table
+		if statsFormat != "json" {
+			statsFormat = "table"
+		}
+	}
+
+	var period service.Period
+	if len(args) == 1 {
+		period = service.Period(args[0])
+		if !service.IsValidPeriod(period) {
+			return fmt.Errorf("invalid period: %s\nValid periods: %v", period, service.AllPeriods)
+		}
+	}
+
+	stats, err := service.ComputeStats(period)
+	if err != nil {
+		return fmt.Errorf("failed to compute stats: %w", err)
+	}
+
+	switch statsFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	case "table":
+	default:
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", statsFormat)
+	}
+
+	if stats.Sessions == 0 {
+		fmt.Println("No entries found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+	table.SetAutoWrapText(false)
+
+	longest := "-"
+	if stats.LongestEntryID != "" {
+		longest = fmt.Sprintf("%s (%s)", formatDurationShort(stats.LongestSession), stats.LongestEntryID)
+	}
+	average := "-"
+	if stats.Sessions > stats.OpenSessions {
+		average = formatDurationShort(stats.AverageSession)
+	}
+	mostUsedProject := "-"
+	if stats.MostUsedProject != "" {
+		mostUsedProject = "@" + stats.MostUsedProject
+	}
+	mostUsedTag := "-"
+	if stats.MostUsedTag != "" {
+		mostUsedTag = "+" + stats.MostUsedTag
+	}
+
+	table.Append([]string{"Total tracked", formatDuration(stats.TotalDuration)})
+	table.Append([]string{"Sessions", fmt.Sprintf("%d", stats.Sessions)})
+	table.Append([]string{"Average session", average})
+	table.Append([]string{"Longest session", longest})
+	table.Append([]string{"Most used project", mostUsedProject})
+	table.Append([]string{"Most used tag", mostUsedTag})
+	table.Render()
+
+	if stats.OpenSessions > 0 {
+		fmt.Printf("\n%d running or paused session(s) excluded from average and longest.\n", stats.OpenSessions)
+	}
+
+	fmt.Println()
+	fmt.Println("By Weekday:")
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetColumnSeparator("")
+	table.SetTablePadding("  ")
+
+	for _, w := range stats.ByWeekday {
+		table.Append([]string{"  " + w.Weekday, formatDurationShort(w.Duration)})
+	}
+	table.Render()
+
+	return nil
+}
@@ -1,14 +1,51 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/color"
+	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
 	"github.com/thinktide/tally/internal/model"
+	"github.com/thinktide/tally/internal/service"
 )
 
+// statusWatch specifies whether [statusCmd] keeps redrawing the status until interrupted.
+//
+// statusInterval defines how often the status is redrawn in watch mode.
+//
+// statusJSON specifies whether [statusCmd] prints machine-readable JSON instead of the human-readable status.
+//
+// statusTimerName names the timer to show, as given to `tally start --timer`.
+var (
+	statusWatch     bool
+	statusInterval  time.Duration
+	statusJSON      bool
+	statusTimerName string
+)
+
+// statusOutput is the JSON shape of `tally status --json`.
+//
+// Running is true while a timer is running or paused; Status tells the two apart. When no timer is active, only
+// Running is set. ElapsedSeconds excludes pauses, matching [model.Entry.Duration].
+type statusOutput struct {
+	Running bool `json:"running"`
+	*model.Entry
+	ProjectName    string   `json:"project_name,omitempty"`
+	TagNames       []string `json:"tag_names,omitempty"`
+	ElapsedSeconds *int64   `json:"elapsed_seconds,omitempty"`
+	PausedSeconds  *int64   `json:"paused_seconds,omitempty"`
+}
+
 // statusCmd provides functionality to display the current timer status.
 //
 // The command retrieves any actively running or paused timer from the database and shows relevant details like project, title, tags,
@@ -16,16 +53,36 @@ import (
 //
 // If there are no active timers, the command informs the user accordingly. It is primarily executed via the [RunE] handler [runStatus].
 var statusCmd = &cobra.Command{
-	Use:   "status",
+	Use:   "status [id]",
 	Short: "Show current timer status",
-	RunE:  runStatus,
+	Long: `Show the running or paused timer. If several named timers are active, all of them are shown.
+
+With --watch, the status is redrawn every --interval until Ctrl-C is pressed
+or the timer is stopped.
+
+Examples:
+  tally status                        # Show the current timer
+  tally status --watch                # Live-updating status
+  tally status --watch --interval 5s  # Redraw every 5 seconds
+  tally status --json                 # For status bars and scripts
+  tally status --timer deep           # Only the timer named 'deep'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
+}
+
+func init() {
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Keep redrawing the status until interrupted")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", time.Second, "Refresh interval for --watch")
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output as JSON")
+	statusCmd.Flags().StringVar(&statusTimerName, "timer", "", "Named timer to show")
 }
 
 // runStatus retrieves the currently running or paused timer entry from the database and prints its status.
 //
-// If no timer is currently running, the message "No timer running" is printed. Otherwise, detailed information
-// about the running or paused timer, including its duration, associated project, title, tags, and pause details,
-// is displayed.
+// If no timer is currently running, the message "No timer running" is printed. If several timers are active and
+// neither an ID nor --timer is given, the status of each is printed; in watch mode they are listed instead and one
+// must be picked (see [getActiveEntry]). Otherwise, detailed information about the
+// running or paused timer, including its duration, associated project, title, tags, and pause details, is displayed.
 //
 // cmd:
 //   - The [cobra.Command] context in which this function is called.
@@ -35,55 +92,304 @@ var statusCmd = &cobra.Command{
 //
 // Returns an [error] if the retrieval of the running entry from the database fails or any other runtime issue occurs.
 func runStatus(cmd *cobra.Command, args []string) error {
-	entry, err := db.GetRunningEntry()
+	if statusJSON {
+		if statusWatch {
+			return fmt.Errorf("--json cannot be combined with --watch")
+		}
+		return printStatusJSON(args)
+	}
+
+	if len(args) == 0 && statusTimerName == "" && !statusWatch {
+		active, err := db.ListActiveEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list active entries: %w", err)
+		}
+		if len(active) > 1 {
+			for i := range active {
+				if i > 0 {
+					fmt.Println()
+				}
+				printStatus(os.Stdout, &active[i])
+				if _, err := warnLongSession(&active[i]); err != nil {
+					return err
+				}
+			}
+			return printGoalProgress()
+		}
+	}
+
+	entry, err := getActiveEntryForTimer(args, statusTimerName)
 	if err != nil {
-		return fmt.Errorf("failed to get running entry: %w", err)
+		return err
 	}
 	if entry == nil {
-		fmt.Println("No timer running")
-		return nil
+		return printGoalProgress()
+	}
+
+	if statusWatch {
+		return watchStatus(entry.ID)
 	}
 
-	printStatus(entry)
+	printStatus(os.Stdout, entry)
+	if _, err := warnLongSession(entry); err != nil {
+		return err
+	}
+	return printGoalProgress()
+}
+
+// goalPeriods pairs each goal setting with the period it applies to and the label shown by [printGoalProgress].
+var goalPeriods = []struct {
+	key    string
+	period service.Period
+	label  string
+}{
+	{config.KeyGoalDaily, service.PeriodToday, "Today"},
+	{config.KeyGoalWeekly, service.PeriodWeek, "This week"},
+}
+
+// printGoalProgress prints the time tracked today and this week against the goal.daily and goal.weekly settings,
+// e.g. "Today: 4h 10m / 6h 0m (1h 50m left)". Goals that are not set are skipped.
+//
+// Returns an error if the settings or entries cannot be read.
+func printGoalProgress() error {
+	printed := false
+	for _, g := range goalPeriods {
+		value, err := config.Get(g.key)
+		if err != nil {
+			return err
+		}
+		goal, err := parseGoal(value)
+		if err != nil || goal == 0 {
+			continue
+		}
+
+		start, end := service.GetPeriodDateRange(g.period)
+		entries, err := db.ListEntries(db.ListEntriesOptions{From: &start, To: &end})
+		if err != nil {
+			return fmt.Errorf("failed to list entries: %w", err)
+		}
+		var total time.Duration
+		for _, e := range entries {
+			total += e.Duration()
+		}
+
+		if !printed {
+			fmt.Println()
+			printed = true
+		}
+		progress := "goal reached"
+		if total < goal {
+			progress = formatDurationShort(goal-total) + " left"
+		}
+		fmt.Printf("%s: %s / %s (%s)\n", g.label, formatDurationShort(total), formatDurationShort(goal), progress)
+	}
 	return nil
 }
 
-// printStatus formats and prints the details of a time entry to the console.
+// parseGoal parses a goal duration such as "6h" or "7h30m". An empty value or "0" means no goal.
+//
+// Returns an error if the value is not a valid non-negative duration.
+func parseGoal(value string) (time.Duration, error) {
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("value must be a duration like 6h or 7h30m, or empty for no goal")
+	}
+	return d, nil
+}
+
+// printStatusJSON prints the active entry, or the one given by ID in args or by --timer, as a [statusOutput].
+//
+// Unlike [getActiveEntry], nothing but JSON is written to stdout: when the default timer has several active entries and
+// no ID is given, an error is returned instead of listing them.
+//
+// Returns an error if the ID cannot be resolved, the default timer has several active entries, or a database query
+// fails.
+func printStatusJSON(args []string) error {
+	var entry *model.Entry
+	if len(args) > 0 {
+		if statusTimerName != "" {
+			return fmt.Errorf("specify either an entry ID or --timer, not both")
+		}
+		var err error
+		entry, err = getActiveEntry(args)
+		if err != nil {
+			return err
+		}
+	} else if statusTimerName != "" {
+		var err error
+		entry, err = db.GetRunningEntryForTimer(statusTimerName)
+		if err != nil {
+			return fmt.Errorf("failed to get running entry: %w", err)
+		}
+	} else {
+		counts, err := db.CountActiveEntries()
+		if err != nil {
+			return fmt.Errorf("failed to count active entries: %w", err)
+		}
+		if counts[""] > 1 {
+			return fmt.Errorf("%d entries are active on the default timer; specify an entry ID", counts[""])
+		}
+		if entry, err = defaultTimerEntry(counts); err != nil {
+			return err
+		}
+	}
+
+	out := statusOutput{}
+	if entry != nil {
+		out.Running = true
+		out.Entry = entry
+		out.ProjectName = entry.Project.Name
+		for _, t := range entry.Tags {
+			out.TagNames = append(out.TagNames, t.Name)
+		}
+		var paused time.Duration
+		for _, p := range entry.Pauses {
+			paused += p.Duration()
+		}
+		elapsed, pausedSeconds := int64(entry.Duration().Seconds()), int64(paused.Seconds())
+		out.ElapsedSeconds = &elapsed
+		out.PausedSeconds = &pausedSeconds
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// watchStatus redraws the status of the entry with the given ID every [statusInterval].
+//
+// The screen is cleared before each redraw. Watching ends cleanly on SIGINT or SIGTERM, or once the entry has been
+// stopped by another tally invocation, in which case its final duration is shown.
+//
+// Returns an error if the interval is not positive or the entry cannot be reloaded.
+func watchStatus(entryID string) error {
+	if statusInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+
+	for {
+		entry, err := db.GetEntryByID(entryID)
+		if err != nil {
+			return fmt.Errorf("failed to reload entry: %w", err)
+		}
+
+		// Clear the screen and move the cursor home
+		fmt.Print("\033[H\033[2J")
+		if entry.Status == model.StatusStopped {
+			printStopped(entry)
+			return nil
+		}
+		printStatus(os.Stdout, entry)
+		if _, err := warnLongSession(entry); err != nil {
+			return err
+		}
+		if err := printGoalProgress(); err != nil {
+			return err
+		}
+		fmt.Println("\nPress Ctrl-C to exit")
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printStatus formats and writes the details of a time entry to w, usually [os.Stdout].
 //
 // The function displays the status (e.g., "Running" or "Paused") along with the project name and, if present, the title and tags.
-// It also shows the start time, elapsed duration, and total pause time with the number of pauses, if applicable.
+// It also shows the start time, elapsed duration, and total pause time with the number of pauses and the time per
+// pause reason, if applicable. While paused, the reason of the open pause is shown as well.
+//
+// w:
+//   - The [io.Writer] the status is written to.
 //
 // entry:
 //   - A pointer to [model.Entry] containing details of the time entry such as start time, status, title, tags, and pauses.
 //
-// The output is formatted into a readable structure for display in a CLI environment.
-func printStatus(entry *model.Entry) {
+// The output is formatted into a readable structure for display in a CLI environment. When colors are on (see
+// [color.Setup]), the status is green while running and yellow while paused, and the project has its own color.
+func printStatus(w io.Writer, entry *model.Entry) {
 	duration := entry.Duration()
-	status := "Running"
+	status := color.Green("[Running]")
 	if entry.Status == model.StatusPaused {
-		status = "Paused"
+		status = color.Yellow("[Paused]")
 	}
 
-	fmt.Printf("[%s] @%s", status, entry.Project.Name)
+	fmt.Fprintf(w, "%s %s", status, color.Project(entry.Project.Name))
 	if entry.Title != "" {
-		fmt.Printf(": %s", entry.Title)
+		fmt.Fprintf(w, ": %s", entry.Title)
 	}
 	if len(entry.Tags) > 0 {
-		fmt.Printf(" [%s]", formatTagsFromModel(entry.Tags))
+		fmt.Fprintf(w, " [%s]", formatTagsFromModel(entry.Tags))
+	}
+	fmt.Fprintf(w, "\n")
+	if entry.Timer != "" {
+		fmt.Fprintf(w, "  Timer:   %s\n", entry.Timer)
+	}
+	if entry.Note != "" {
+		fmt.Fprintf(w, "  Note:    %s\n", entry.Note)
+	}
+	fmt.Fprintf(w, "  Started: %s\n", entry.StartTime.Format("15:04:05"))
+	fmt.Fprintf(w, "  Elapsed: %s\n", formatDuration(duration))
+	if entry.PlannedEnd != nil {
+		fmt.Fprintf(w, "  Until:   %s\n", formatPlannedEnd(*entry.PlannedEnd))
 	}
-	fmt.Printf("\n")
-	fmt.Printf("  Started: %s\n", entry.StartTime.Format("15:04:05"))
-	fmt.Printf("  Elapsed: %s\n", formatDuration(duration))
 
 	if len(entry.Pauses) > 0 {
 		var totalPause time.Duration
+		byReason := make(map[string]time.Duration)
 		for _, p := range entry.Pauses {
 			totalPause += p.Duration()
+			byReason[p.Reason] += p.Duration()
+		}
+		reasons := make([]string, 0, len(byReason))
+		for _, reason := range service.SortByDuration(byReason) {
+			reasons = append(reasons, fmt.Sprintf("%s %s", reason, formatDurationShort(byReason[reason])))
+		}
+		fmt.Fprintf(w, "  Paused:  %s (%d pause(s): %s)\n", formatDuration(totalPause), len(entry.Pauses), strings.Join(reasons, ", "))
+	}
+	if entry.Status == model.StatusPaused {
+		for _, p := range entry.Pauses {
+			if p.ResumeTime == nil {
+				fmt.Fprintf(w, "  Reason:  %s (since %s)\n", p.Reason, p.PauseTime.Format("15:04:05"))
+			}
 		}
-		fmt.Printf("  Paused:  %s (%d pause(s))\n", formatDuration(totalPause), len(entry.Pauses))
 	}
 }
 
+// warnLongSession prints a warning when entry has been active for longer than the max_session setting, suggesting
+// `tally stop --at`. The wall-clock span since the start is used, pauses included, since a forgotten timer is often
+// paused rather than running.
+//
+// Returns whether the warning was printed, or an error if the setting cannot be read.
+func warnLongSession(entry *model.Entry) (bool, error) {
+	limit, err := config.MaxSession()
+	if err != nil || limit == 0 {
+		return false, err
+	}
+	span := time.Since(entry.StartTime)
+	if span <= limit {
+		return false, nil
+	}
+
+	fmt.Printf("\n!! This timer started %s ago, longer than max_session (%s).\n",
+		formatDurationShort(span), formatDurationShort(limit))
+	fmt.Println("!! Forgot to stop it? Use 'tally stop --at <time>' to record when you actually stopped.")
+	return true, nil
+}
+
 // formatDuration formats a [time.Duration] into a human-readable string with hours, minutes, and seconds.
 //
 // The function rounds the duration to the nearest second and returns a string representation:
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/model"
+)
+
+func TestPrintStatusGolden(t *testing.T) {
+	plainOutput(t)
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	// The end time pins the elapsed time, which would otherwise be measured up to now
+	at := func(d time.Duration) *time.Time { t := start.Add(d); return &t }
+
+	tests := []struct {
+		name  string
+		entry model.Entry
+	}{
+		{
+			name: "status_running",
+			entry: model.Entry{
+				Project:   &model.Project{Name: "work"},
+				StartTime: start,
+				EndTime:   at(90 * time.Minute),
+				Status:    model.StatusRunning,
+			},
+		},
+		{
+			name: "status_detailed",
+			entry: model.Entry{
+				Project:    &model.Project{Name: "client"},
+				Title:      "Homepage redesign",
+				Timer:      "deep",
+				Note:       "Second round of mockups",
+				Tags:       []model.Tag{{Name: "design"}, {Name: "client"}},
+				StartTime:  start,
+				EndTime:    at(3 * time.Hour),
+				PlannedEnd: at(4 * time.Hour),
+				Status:     model.StatusRunning,
+				Pauses: []model.Pause{
+					{PauseTime: *at(time.Hour), ResumeTime: at(time.Hour + 10*time.Minute), Reason: "Manual"},
+					{PauseTime: *at(2 * time.Hour), ResumeTime: at(2*time.Hour + 30*time.Minute), Reason: "Lunch"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printStatus(&buf, &tt.entry)
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
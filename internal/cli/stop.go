@@ -2,9 +2,26 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// stopAt specifies a past time to stop the entry at instead of now.
+//
+// stopTimerName names the timer to stop, as given to `tally start --timer`.
+//
+// stopDiscard deletes the entry instead of stopping it, for a timer started by mistake.
+//
+// stopForce skips the confirmation prompt of --discard.
+var (
+	stopAt        string
+	stopTimerName string
+	stopDiscard   bool
+	stopForce     bool
 )
 
 // stopCmd is a CLI command used to stop the currently running time entry.
@@ -16,35 +33,79 @@ import (
 // Errors are returned if the database fails to fetch the running entry or to update the entry's status. The output
 // also includes relevant details like the associated project and title when available.
 var stopCmd = &cobra.Command{
-	Use:   "stop",
+	Use:   "stop [id]",
 	Short: "Stop the current time entry",
-	RunE:  runStop,
+	Long: `Stop the current time entry.
+
+If several timers are active, they are listed and an entry ID (or unique prefix) must be given to choose one.
+
+Examples:
+  tally stop                           # Stop now
+  tally stop --at 18:00                # Stop today at 18:00
+  tally stop --at "2024-01-01 18:00"   # Stop a timer left running overnight
+  tally stop --timer deep              # Stop the timer named 'deep'
+  tally stop --discard                 # Throw the timer away without recording it`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStop,
+}
+
+// init configures flags for the [stopCmd] command.
+func init() {
+	stopCmd.Flags().StringVar(&stopAt, "at", "", "Stop time (HH:MM or YYYY-MM-DD HH:MM:SS)")
+	stopCmd.Flags().StringVar(&stopTimerName, "timer", "", "Named timer to stop")
+	stopCmd.Flags().BoolVar(&stopDiscard, "discard", false, "Delete the entry instead of saving it")
+	stopCmd.Flags().BoolVarP(&stopForce, "force", "f", false, "Skip the confirmation prompt of --discard")
 }
 
 // runStop stops the currently running time entry.
 //
-// If there is no running timer, the function prints a message indicating this and exits without error.
+// If there is no running timer, the function prints a message indicating this and exits without error. If several
+// timers are active and neither an ID nor --timer is given, they are listed instead (see [getActiveEntry]). With --at, the entry
+// is stopped at that time instead of now, which must not be before its start or its latest pause. With --discard,
+// the entry is deleted instead (see [discardEntry]).
 //
 // The function interacts with the database to stop the running entry and reloads it to retrieve updated details.
 // It calculates and formats the time duration between the start and stop of the entry.
 //
 // Errors:
 //   - Returns an error if fetching the running entry fails.
+//   - Returns an error if the --at time is invalid or out of range.
 //   - Returns an error if stopping the entry in the database fails.
 //   - Returns an error if the reloaded entry cannot be fetched.
 //
 // Prints a message summarizing the stopped timer, including the project name, optional title, and duration.
 func runStop(cmd *cobra.Command, args []string) error {
-	entry, err := db.GetRunningEntry()
+	if stopDiscard && stopAt != "" {
+		return fmt.Errorf("--discard cannot be combined with --at")
+	}
+	cmd.SilenceUsage = true
+
+	entry, err := getActiveEntryForTimer(args, stopTimerName)
 	if err != nil {
-		return fmt.Errorf("failed to get running entry: %w", err)
+		return err
 	}
 	if entry == nil {
-		fmt.Println("No timer running")
+		if stopDiscard {
+			return fmt.Errorf("no timer to discard")
+		}
 		return nil
 	}
+	if stopDiscard {
+		return discardEntry(entry)
+	}
 
-	if err := db.StopEntry(entry.ID); err != nil {
+	stopTime := db.Now()
+	if stopAt != "" {
+		stopTime, err = parseTimeInput(stopAt)
+		if err != nil {
+			return err
+		}
+		if err := validateStopTime(entry, stopTime); err != nil {
+			return err
+		}
+	}
+
+	if err := db.StopEntryAt(entry.ID, stopTime); err != nil {
 		return fmt.Errorf("failed to stop entry: %w", err)
 	}
 
@@ -54,12 +115,63 @@ func runStop(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to reload entry: %w", err)
 	}
 
-	duration := entry.Duration()
+	printStopped(entry)
+	return nil
+}
+
+// discardEntry deletes the active entry with [db.DeleteEntry], along with its tags and pauses, after asking for
+// confirmation with [confirmDelete] unless --force is set.
+func discardEntry(entry *model.Entry) error {
+	if !stopForce {
+		printStatus(os.Stdout, entry)
+		fmt.Println()
+		ok, err := confirmDelete("Discard this timer? It will not be recorded.", entry.Project.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	if err := db.DeleteEntry(entry.ID); err != nil {
+		return fmt.Errorf("failed to discard entry: %w", err)
+	}
+
+	fmt.Printf("Discarded timer for @%s", entry.Project.Name)
+	if entry.Title != "" {
+		fmt.Printf(": %s", entry.Title)
+	}
+	fmt.Printf(" [%s elapsed]\n", formatDuration(entry.Duration()))
+	return nil
+}
+
+// printStopped prints a one-line summary of a stopped entry, including its project, optional title, and duration.
+func printStopped(entry *model.Entry) {
 	fmt.Printf("Stopped timer for @%s", entry.Project.Name)
 	if entry.Title != "" {
 		fmt.Printf(": %s", entry.Title)
 	}
-	fmt.Printf(" [%s]\n", formatDuration(duration))
+	fmt.Printf(" [%s]\n", formatDuration(entry.Duration()))
+}
 
+// validateStopTime checks that stopTime is not in the future and falls after the entry's start and latest pause.
+func validateStopTime(entry *model.Entry, stopTime time.Time) error {
+	if stopTime.After(db.Now()) {
+		return fmt.Errorf("stop time cannot be in the future")
+	}
+	if !stopTime.After(entry.StartTime) {
+		return fmt.Errorf("stop time must be after entry start time (%s)", entry.StartTime.Format("2006-01-02 15:04:05"))
+	}
+	for _, p := range entry.Pauses {
+		latest := p.PauseTime
+		if p.ResumeTime != nil {
+			latest = *p.ResumeTime
+		}
+		if stopTime.Before(latest) {
+			return fmt.Errorf("stop time cannot be before the latest pause (%s)", latest.Format("2006-01-02 15:04:05"))
+		}
+	}
 	return nil
 }
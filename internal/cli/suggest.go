@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// suggestProject is called after no project named name was found. It prints the closest project names from
+// [db.SuggestProject] to w and, when interactive, offers to use the closest one instead.
+//
+// Returns the project the user accepted, or nil if there was no close match or the user declined.
+func suggestProject(w io.Writer, name string) (*model.Project, error) {
+	matches, err := db.SuggestProject(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest projects: %w", err)
+	}
+	chosen, err := offerSuggestion(w, "@", matches)
+	if err != nil || chosen == "" {
+		return nil, err
+	}
+	project, err := db.GetProjectByName(chosen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// suggestTag is like [suggestProject] for tags, using [db.SuggestTag].
+func suggestTag(w io.Writer, name string) (*model.Tag, error) {
+	matches, err := db.SuggestTag(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+	chosen, err := offerSuggestion(w, "+", matches)
+	if err != nil || chosen == "" {
+		return nil, err
+	}
+	tag, err := db.GetTagByName(chosen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return tag, nil
+}
+
+// offerSuggestion shows matches, each prefixed with sigil, as a "Did you mean" hint on w.
+//
+// When stdin is a terminal, the user is asked on w whether they meant the first match, and that name is returned if
+// they agree. Otherwise, the matches are only printed, so scripts see the hint without blocking on a prompt.
+//
+// Returns the accepted name, or an empty string if matches is empty or nothing was accepted.
+func offerSuggestion(w io.Writer, sigil string, matches []string) (string, error) {
+	if len(matches) == 0 {
+		return "", nil
+	}
+
+	if !isInteractive() {
+		fmt.Fprintf(w, "Did you mean %s?\n", joinSuggestions(sigil, matches))
+		return "", nil
+	}
+
+	ok, err := confirmOn(w, fmt.Sprintf("Did you mean %s%s?", sigil, matches[0]), true)
+	if err != nil || !ok {
+		return "", err
+	}
+	return matches[0], nil
+}
+
+// notFoundError returns the error for a missing project or tag called name, such as "project @wrok not found", with
+// matches, each prefixed with sigil, as a "did you mean" hint. It is used instead of [suggestProject] and [suggestTag]
+// where prompting is not possible.
+func notFoundError(kind, sigil, name string, matches []string) error {
+	if len(matches) == 0 {
+		return fmt.Errorf("%s %s%s not found", kind, sigil, name)
+	}
+	return fmt.Errorf("%s %s%s not found (did you mean %s?)", kind, sigil, name, joinSuggestions(sigil, matches))
+}
+
+// joinSuggestions prefixes each of matches with sigil and joins them with "or", e.g. "@work or @works".
+func joinSuggestions(sigil string, matches []string) string {
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = sigil + m
+	}
+	return strings.Join(names, " or ")
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// tagFrom and tagTo restrict [tagCmd] subcommands to entries starting within the given dates, inclusive.
+//
+// tagDryRun specifies whether the entries that would change are only listed, without modifying them.
+var (
+	tagFrom   string
+	tagTo     string
+	tagDryRun bool
+)
+
+// tagCmd groups commands that add a tag to, or remove it from, many existing entries at once.
+//
+// Entries are selected with the same @project, +tag, --from, and --to filters as [logCmd]. At least one filter is
+// required so a mistyped command cannot retag every entry.
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove a tag on many entries",
+	Long: `Add a tag to, or remove it from, every entry matching the filters.
+
+Entries are selected with @project, +tag, --from, and --to, as in 'tally log'.
+
+Examples:
+  tally tag add +billed @work --from 2024-01-01 --to 2024-01-31
+  tally tag remove +billed @work --from 2024-01-01 --dry-run`,
+}
+
+// tagAddCmd adds a tag to every matching entry, creating the tag if needed.
+var tagAddCmd = &cobra.Command{
+	Use:   "add <+tag> [@project] [+tag]... [--from <date>] [--to <date>]",
+	Short: "Add a tag to matching entries",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagBulk(cmd, args, true)
+	},
+}
+
+// tagRemoveCmd removes a tag from every matching entry. The tag itself is kept.
+var tagRemoveCmd = &cobra.Command{
+	Use:   "remove <+tag> [@project] [+tag]... [--from <date>] [--to <date>]",
+	Short: "Remove a tag from matching entries",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagBulk(cmd, args, false)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{tagAddCmd, tagRemoveCmd} {
+		c.Flags().StringVar(&tagFrom, "from", "", "Start date (YYYY-MM-DD)")
+		c.Flags().StringVar(&tagTo, "to", "", "End date (YYYY-MM-DD)")
+		c.Flags().BoolVar(&tagDryRun, "dry-run", false, "List the entries that would change without changing them")
+		tagCmd.AddCommand(c)
+	}
+}
+
+// runTagBulk adds (when add is true) or removes the tag named by args[0] on the entries matched by the remaining
+// arguments and the date flags. Only entries that would actually change are counted and listed.
+func runTagBulk(cmd *cobra.Command, args []string, add bool) error {
+	if !strings.HasPrefix(args[0], "+") || len(args[0]) == 1 {
+		return fmt.Errorf("first argument must be a tag (+name)")
+	}
+	tagName := strings.TrimPrefix(args[0], "+")
+	filters := args[1:]
+	if len(filters) == 0 && tagFrom == "" && tagTo == "" {
+		return fmt.Errorf("at least one filter (@project, +tag, --from, or --to) is required")
+	}
+	cmd.SilenceUsage = true
+
+	opts, err := parseEntryFilter(filters, tagFrom, tagTo)
+	if err != nil {
+		return err
+	}
+	entries, err := db.ListEntries(opts)
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	var targets []model.Entry
+	for _, e := range entries {
+		if hasTag(e, tagName) != add {
+			targets = append(targets, e)
+		}
+	}
+
+	verb, prep := "Removed", "from"
+	if add {
+		verb, prep = "Added", "to"
+	}
+	if len(targets) == 0 {
+		fmt.Println("No matching entries to change")
+		return nil
+	}
+	if tagDryRun {
+		printEntriesTable(os.Stdout, targets)
+		fmt.Printf("\nDry run: would have %s +%s %s %d entries\n", strings.ToLower(verb), tagName, prep, len(targets))
+		return nil
+	}
+
+	ids := make([]string, len(targets))
+	for i, e := range targets {
+		ids[i] = e.ID
+	}
+
+	var changed int
+	if add {
+		tag, err := db.GetOrCreateTag(tagName)
+		if err != nil {
+			return fmt.Errorf("failed to get/create tag '%s': %w", tagName, err)
+		}
+		changed, err = db.AddTagToEntries(tag.ID, ids)
+		if err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+	} else {
+		tag, err := db.GetTagByName(tagName)
+		if err != nil {
+			return fmt.Errorf("failed to get tag: %w", err)
+		}
+		changed, err = db.RemoveTagFromEntries(tag.ID, ids)
+		if err != nil {
+			return fmt.Errorf("failed to remove tag: %w", err)
+		}
+	}
+
+	fmt.Printf("%s +%s %s %d entries\n", verb, tagName, prep, changed)
+	return nil
+}
+
+// hasTag reports whether e has a tag with the given name.
+func hasTag(e model.Entry, name string) bool {
+	for _, t := range e.Tags {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
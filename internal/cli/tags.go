@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/config"
+	"github.com/thinktide/tally/internal/db"
+)
+
+// tagsFormat defines the output format of [tagsCmd]. Accepts "table" (default) or "json".
+var tagsFormat string
+
+// tagsCmd lists all tags with the number of entries and the total time tracked for each.
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List, rename, and delete tags",
+	Long: `List all tags with their entry counts and total tracked time.
+
+Examples:
+  tally tags                        # List tags
+  tally tags --format json          # Output as JSON
+  tally tags rename +backedn +backend
+  tally tags delete +typo`,
+	Args: cobra.NoArgs,
+	RunE: runTags,
+}
+
+// tagsRenameCmd renames a tag; it is equivalent to `tally rename +old +new`.
+var tagsRenameCmd = &cobra.Command{
+	Use:   "rename <+old> <+new>",
+	Short: "Rename a tag",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !strings.HasPrefix(args[0], "+") || !strings.HasPrefix(args[1], "+") {
+			return fmt.Errorf("both names must be tags (+name)")
+		}
+		return runRename(cmd, args)
+	},
+}
+
+// tagsDeleteCmd deletes a tag after confirmation, detaching it from its entries without deleting them.
+var tagsDeleteCmd = &cobra.Command{
+	Use:   "delete <+name>",
+	Short: "Delete a tag, keeping its entries",
+	Long:  `Delete a tag and remove it from every entry. The entries themselves are kept.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTagsDelete,
+}
+
+// tagSummary is a row of the tags listing.
+type tagSummary struct {
+	db.TagUsage
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+// init configures flags and subcommands for the [tagsCmd] command.
+func init() {
+	tagsCmd.Flags().StringVar(&tagsFormat, "format", "table", "Output format: table, json")
+	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsDeleteCmd)
+}
+
+// runTags lists tags using [db.ListTags], adding each tag's total time from its entries.
+//
+// Returns an error if the tags or entries cannot be loaded, or the format is unknown.
+func runTags(cmd *cobra.Command, args []string) error {
+	tags, err := db.ListTags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	entries, err := db.ListEntries(db.ListEntriesOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+	totals := make(map[string]time.Duration)
+	for _, e := range entries {
+		for _, t := range e.Tags {
+			totals[t.ID] += e.Duration()
+		}
+	}
+
+	summaries := make([]tagSummary, len(tags))
+	for i, t := range tags {
+		summaries[i] = tagSummary{TagUsage: t, TotalDuration: totals[t.ID]}
+	}
+
+	switch tagsFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summaries)
+	case "table":
+	default:
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", tagsFormat)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No tags found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Tag", "Entries", "Total"})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	for _, t := range summaries {
+		table.Append([]string{"+" + t.Name, strconv.Itoa(t.Entries), formatDurationShort(t.TotalDuration)})
+	}
+
+	table.Render()
+	return nil
+}
+
+func runTagsDelete(cmd *cobra.Command, args []string) error {
+	if !strings.HasPrefix(args[0], "+") {
+		return fmt.Errorf("tag name must start with +")
+	}
+	name := strings.TrimPrefix(args[0], "+")
+	cmd.SilenceUsage = true
+
+	tag, err := db.GetTagByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to look up tag: %w", err)
+	}
+	if tag == nil {
+		fmt.Printf("No tag named +%s\n", name)
+		return nil
+	}
+
+	defaultYes, err := config.GetBool(config.KeyDeleteDefaultYes)
+	if err != nil {
+		return err
+	}
+	ok, err := confirm(fmt.Sprintf("Delete tag +%s? Entries using it are kept.", name), defaultYes)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Cancelled")
+		return nil
+	}
+
+	detached, err := db.DeleteTag(tag.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	fmt.Printf("Deleted +%s (removed from %d entries)\n", name, detached)
+	return nil
+}
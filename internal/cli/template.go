@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// templateFormat defines the output format of [templateListCmd]. Accepts "table" (default) or "json".
+var templateFormat string
+
+// templateCmd groups commands that save and start entry templates: a project, title, and tags started together by
+// name, for entries that repeat every day.
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Save and start entry templates",
+	Long: `Save a project, title, and tags under a name, and start entries from it.
+
+Examples:
+  tally template save morning @work "Standup" +meeting
+  tally template start morning      # Same as: tally start --template morning
+  tally template list
+  tally template delete morning`,
+}
+
+// templateSaveCmd saves a template, replacing any template with the same name.
+var templateSaveCmd = &cobra.Command{
+	Use:   "save <name> @project [\"title\"] [+tag]...",
+	Short: "Save a template",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runTemplateSave,
+}
+
+// templateStartCmd starts an entry from a template, like `tally start --template`.
+var templateStartCmd = &cobra.Command{
+	Use:   "start <name>",
+	Short: "Start an entry from a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		startTemplate = args[0]
+		return runStart(cmd, nil)
+	},
+}
+
+// templateListCmd lists the saved templates.
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+// templateDeleteCmd deletes a template. Entries started from it are kept.
+var templateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateDelete,
+}
+
+func init() {
+	templateStartCmd.Flags().BoolVar(&startForce, "force", false, "Stop a running or paused timer and start the new one")
+	templateStartCmd.Flags().StringVar(&startTimerName, "timer", "", "Named timer to start, so several can run at once")
+	templateStartCmd.Flags().BoolVar(&startBillable, "billable", true, "Mark the entry as billable (--billable=false for non-billable work)")
+	templateStartCmd.Flags().StringVarP(&startMessage, "message", "m", "", "Title of the entry, instead of the template's title")
+	templateListCmd.Flags().StringVar(&templateFormat, "format", "table", "Output format: table, json")
+
+	templateCmd.AddCommand(templateSaveCmd)
+	templateCmd.AddCommand(templateStartCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateDeleteCmd)
+}
+
+// runTemplateSave saves the template named by args[0] from the start-style arguments that follow, creating the
+// project and tags if needed.
+func runTemplateSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if strings.HasPrefix(name, "@") || strings.HasPrefix(name, "+") {
+		return fmt.Errorf("template name must not start with @ or +")
+	}
+	projectName, title, tagNames, err := parseStartArgs(args[1:], "")
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	project, err := db.GetOrCreateProject(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to get/create project: %w", err)
+	}
+	var tagIDs []string
+	for _, tagName := range tagNames {
+		tag, err := db.GetOrCreateTag(tagName)
+		if err != nil {
+			return fmt.Errorf("failed to get/create tag '%s': %w", tagName, err)
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	if err := db.SaveTemplate(name, project.ID, title, tagIDs); err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+	fmt.Printf("Saved template '%s': %s\n", name, formatTemplate(&model.Template{Project: project, Title: title, Tags: tagsFromNames(tagNames)}))
+	return nil
+}
+
+// runTemplateList lists templates using [db.ListTemplates] in the requested format.
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	if templateFormat != "table" && templateFormat != "json" {
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", templateFormat)
+	}
+	cmd.SilenceUsage = true
+
+	templates, err := db.ListTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	if templateFormat == "json" {
+		if templates == nil {
+			templates = []model.Template{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(templates)
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No templates found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Template", "Starts"})
+	table.SetBorder(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetTablePadding("  ")
+	table.SetNoWhiteSpace(true)
+
+	for i := range templates {
+		table.Append([]string{templates[i].Name, formatTemplate(&templates[i])})
+	}
+	table.Render()
+	return nil
+}
+
+// runTemplateDelete deletes the template named by args[0] with [db.DeleteTemplate].
+func runTemplateDelete(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	deleted, err := db.DeleteTemplate(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if !deleted {
+		fmt.Printf("No template named '%s'\n", args[0])
+		return nil
+	}
+	fmt.Printf("Deleted template '%s'\n", args[0])
+	return nil
+}
+
+// expandTemplate looks up the template called name and returns the start-style arguments for its project and tags,
+// along with its title. The title is returned separately so that one starting with "@" or "+" is kept as is.
+//
+// Returns an error if the template does not exist or cannot be loaded.
+func expandTemplate(name string) (args []string, title string, err error) {
+	t, err := db.GetTemplate(name)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get template: %w", err)
+	}
+	if t == nil {
+		return nil, "", fmt.Errorf("no template named '%s' (see 'tally template list')", name)
+	}
+
+	args = []string{"@" + t.Project.Name}
+	for _, tag := range t.Tags {
+		args = append(args, "+"+tag.Name)
+	}
+	return args, t.Title, nil
+}
+
+// formatTemplate describes what t starts, e.g. `@work: Standup [+meeting]`.
+func formatTemplate(t *model.Template) string {
+	s := "@" + t.Project.Name
+	if t.Title != "" {
+		s += ": " + t.Title
+	}
+	if len(t.Tags) > 0 {
+		s += " [" + formatTagsFromModel(t.Tags) + "]"
+	}
+	return s
+}
+
+// tagsFromNames returns tags with only their names set, for display with [formatTagsFromModel].
+func tagsFromNames(names []string) []model.Tag {
+	tags := make([]model.Tag, len(names))
+	for i, name := range names {
+		tags[i] = model.Tag{Name: name}
+	}
+	return tags
+}
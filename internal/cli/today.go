@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+	"github.com/thinktide/tally/internal/service"
+)
+
+// todayFormat defines the output format of [todayCmd]. Accepts "table" (default) or "json".
+var todayFormat string
+
+// todayCmd is a compact daily overview: the active timers, today's entries, and the time worked today.
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Show active timers and today's entries",
+	Long: `Show the running or paused timers, the entries started today, and the
+total time worked today.
+
+Examples:
+  tally today
+  tally today --format json`,
+	Args: cobra.NoArgs,
+	RunE: runToday,
+}
+
+// todayOutput is the JSON representation printed by [todayCmd].
+type todayOutput struct {
+	Active       []model.Entry `json:"active"`
+	Entries      []model.Entry `json:"entries"`
+	TotalSeconds int64         `json:"total_seconds"`
+}
+
+func init() {
+	todayCmd.Flags().StringVar(&todayFormat, "format", "table", "Output format: table, json")
+}
+
+// runToday prints the active timers with [printStatus], today's entries with [printEntriesTable], and their total.
+//
+// Returns an error if the format is unknown or the entries cannot be loaded.
+func runToday(cmd *cobra.Command, args []string) error {
+	if todayFormat != "table" && todayFormat != "json" {
+		return fmt.Errorf("invalid format: %s (use 'table' or 'json')", todayFormat)
+	}
+	cmd.SilenceUsage = true
+
+	active, err := db.ListActiveEntries()
+	if err != nil {
+		return fmt.Errorf("failed to get active entries: %w", err)
+	}
+
+	start, end := service.GetPeriodDateRange(service.PeriodToday)
+	entries, err := db.ListEntries(db.ListEntriesOptions{From: &start, To: &end})
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	var total time.Duration
+	for _, e := range entries {
+		total += e.Duration()
+	}
+
+	if todayFormat == "json" {
+		out := todayOutput{Active: active, Entries: entries, TotalSeconds: int64(total.Seconds())}
+		if out.Active == nil {
+			out.Active = []model.Entry{}
+		}
+		if out.Entries == nil {
+			out.Entries = []model.Entry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out)
+	}
+
+	if len(active) == 0 {
+		fmt.Println("No timer running")
+	}
+	for i := range active {
+		printStatus(os.Stdout, &active[i])
+	}
+	fmt.Println()
+
+	if len(entries) == 0 {
+		fmt.Println("No entries today")
+	} else {
+		printEntriesTable(os.Stdout, entries)
+	}
+	fmt.Printf("\nToday: %s\n", formatDuration(total))
+	return nil
+}
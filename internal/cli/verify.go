@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/service"
+)
+
+// verifyFix specifies whether [verifyCmd] clamps out-of-range and overlapping pauses to repair the problems it finds.
+var verifyFix bool
+
+// verifyCmd checks every entry's duration and pause math for integrity problems.
+//
+// Unlike a broad database check, it focuses on data that silently distorts reports: negative or implausibly zero
+// durations, pauses outside their entry, and overlapping pauses. With --fix, pauses are clamped to the entry's window.
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check entry durations and pauses for inconsistencies",
+	Long: `Recompute every entry's duration and check its pauses.
+
+Reports entries with a negative duration, a zero duration despite a long
+span, pauses outside the entry's start/end window, and overlapping pauses.
+
+Examples:
+  tally verify         # Report problems
+  tally verify --fix   # Clamp pauses to each entry's window and report fixes`,
+	Args: cobra.NoArgs,
+	RunE: runVerify,
+}
+
+// init configures flags for the [verifyCmd] command.
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyFix, "fix", false, "Clamp pauses to the entry window and remove overlaps")
+}
+
+// runVerify checks all entries with [service.VerifyEntry] and prints the problems found for each.
+//
+// With --fix, the pauses of each problematic entry are adjusted with [service.ClampPauses], and the resulting changes
+// are written back with [db.UpdatePause] and [db.DeletePause].
+//
+// Returns an error if the entries cannot be loaded or a fix cannot be saved.
+func runVerify(cmd *cobra.Command, args []string) error {
+	entries, err := db.ListEntries(db.ListEntriesOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+
+	found, fixed := 0, 0
+	for i := range entries {
+		e := &entries[i]
+		problems := service.VerifyEntry(e)
+		if len(problems) == 0 {
+			continue
+		}
+		found++
+
+		fmt.Printf("%s @%s", e.ID, e.Project.Name)
+		if e.Title != "" {
+			fmt.Printf(": %s", e.Title)
+		}
+		fmt.Printf(" (%s)\n", e.StartTime.Format("2006-01-02 15:04"))
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+
+		if !verifyFix {
+			continue
+		}
+
+		kept, removed := service.ClampPauses(e)
+		for _, p := range kept {
+			if err := db.UpdatePause(p.ID, p.PauseTime, p.ResumeTime); err != nil {
+				return fmt.Errorf("failed to update pause: %w", err)
+			}
+		}
+		for _, p := range removed {
+			if err := db.DeletePause(p.ID); err != nil {
+				return fmt.Errorf("failed to delete pause: %w", err)
+			}
+		}
+
+		reloaded, err := db.GetEntryByID(e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to reload entry: %w", err)
+		}
+		fmt.Printf("  Fixed: clamped %d pause(s), removed %d; duration is now %s\n",
+			len(kept), len(removed), formatDuration(reloaded.Duration()))
+		if remaining := service.VerifyEntry(reloaded); len(remaining) > 0 {
+			fmt.Printf("  Still inconsistent: %d problem(s) need manual editing\n", len(remaining))
+		} else {
+			fixed++
+		}
+	}
+
+	if found == 0 {
+		fmt.Println("All entries are consistent")
+		return nil
+	}
+
+	fmt.Println()
+	if verifyFix {
+		fmt.Printf("Entries with problems: %d, fixed: %d\n", found, fixed)
+	} else {
+		fmt.Printf("Entries with problems: %d (run with --fix to repair pauses)\n", found)
+	}
+	return nil
+}
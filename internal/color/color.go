@@ -0,0 +1,109 @@
+// Package color adds ANSI colors to terminal output.
+//
+// Colors are off until [Setup] turns them on, so output written before it, or by commands that never call it, stays
+// plain. Only human-readable output should be colored; JSON, CSV, Markdown, and HTML are written without escape codes.
+package color
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+)
+
+// Auto, Always, and Never are the accepted values of the --color flag. Auto colors output only when stdout is a
+// terminal and the NO_COLOR environment variable is not set (see https://no-color.org).
+const (
+	Auto   = "auto"
+	Always = "always"
+	Never  = "never"
+)
+
+// ANSI escape codes for the colors used by tally.
+const (
+	reset  = "\033[0m"
+	bold   = "\033[1m"
+	green  = "\033[32m"
+	yellow = "\033[33m"
+	dim    = "\033[2m"
+)
+
+// projectColors is the palette [Project] picks from. Green and yellow are left out, since they mark running and
+// paused timers.
+var projectColors = []string{
+	"\033[34m", // blue
+	"\033[35m", // magenta
+	"\033[36m", // cyan
+	"\033[94m", // bright blue
+	"\033[95m", // bright magenta
+	"\033[96m", // bright cyan
+}
+
+// enabled specifies whether the helpers in this package add escape codes.
+var enabled bool
+
+// Setup turns colors on or off for the rest of the process according to mode, one of [Auto], [Always], or [Never].
+//
+// Returns an error if mode is not one of them.
+func Setup(mode string) error {
+	switch mode {
+	case Always:
+		enabled = true
+	case Never:
+		enabled = false
+	case Auto:
+		enabled = os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(os.Stdout)
+	default:
+		return fmt.Errorf("invalid color mode: %s (use 'auto', 'always', or 'never')", mode)
+	}
+	return nil
+}
+
+// Enabled reports whether colors are on.
+func Enabled() bool {
+	return enabled
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// wrap returns s surrounded by code and a reset, or s unchanged when colors are off.
+func wrap(code, s string) string {
+	if !enabled || s == "" {
+		return s
+	}
+	return code + s + reset
+}
+
+// Green returns s in green, the color of running timers.
+func Green(s string) string {
+	return wrap(green, s)
+}
+
+// Yellow returns s in yellow, the color of paused timers.
+func Yellow(s string) string {
+	return wrap(yellow, s)
+}
+
+// Bold returns s in bold.
+func Bold(s string) string {
+	return wrap(bold, s)
+}
+
+// Dim returns s in a faint color, for secondary details such as IDs.
+func Dim(s string) string {
+	return wrap(dim, s)
+}
+
+// Project returns the project name prefixed with "@", in a color picked from a hash of the name, so a project has
+// the same color in every command and every run.
+func Project(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return wrap(projectColors[h.Sum32()%uint32(len(projectColors))], "@"+name)
+}
@@ -1,16 +1,54 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/thinktide/tally/internal/db"
 )
 
 // KeyOutputFormat is the configuration key for specifying the format of the output.
 // KeyDataLocation is the configuration key for specifying the location of the data.
+// KeyStartAutoStopPrevious is the configuration key for stopping the running timer when a new one is started.
+// KeyDeleteDefaultYes is the configuration key for making delete confirmation prompts default to yes.
+// KeyDeleteRequireTypedConfirmation is the configuration key for requiring the project name to be typed to delete.
+// KeyReportTagSeparator is the configuration key for the separator between a tag's prefix and value (e.g. "client:acme").
+// KeyReportRounding is the configuration key for the increment report durations are rounded up to (e.g. "15m", or "0" for none).
+// KeyReportCurrency is the configuration key for the currency symbol shown with billable amounts.
+// KeyReportWeekStart is the configuration key for the first day of the week ("monday" or "sunday").
+// KeyReportDefaultPeriod is the configuration key for the period reported when none is given ("menu" to ask).
+// KeyTimezone is the configuration key for the IANA time zone used for input and display (empty for the system zone).
+// KeyGoalDaily is the configuration key for the daily tracked-time goal shown by status (e.g. "6h", empty for none).
+// KeyGoalWeekly is the configuration key for the weekly tracked-time goal shown by status (e.g. "30h", empty for none).
+// KeyIdleThreshold is the configuration key for how long without `tally ping` counts as idle (e.g. "15m", empty for off).
+// KeySleepDetection is the configuration key for recording system sleep as pauses before status, stop, pause, and report.
+// KeyMaxSession is the configuration key for how long a timer may run before status and start warn (e.g. "12h", empty for off).
 const (
-	KeyOutputFormat = "output.format"
-	KeyDataLocation = "data.location"
+	KeyOutputFormat                   = "output.format"
+	KeyDataLocation                   = "data.location"
+	KeyStartAutoStopPrevious          = "start.auto_stop_previous"
+	KeyDeleteDefaultYes               = "delete.default_yes"
+	KeyDeleteRequireTypedConfirmation = "delete.require_typed_confirmation"
+	KeyReportTagSeparator             = "report.tag_separator"
+	KeyReportRounding                 = "report.rounding"
+	KeyReportCurrency                 = "report.currency"
+	KeyReportWeekStart                = "report.week_start"
+	KeyReportDefaultPeriod            = "report.default_period"
+	KeyTimezone                       = "timezone"
+	KeyGoalDaily                      = "goal.daily"
+	KeyGoalWeekly                     = "goal.weekly"
+	KeyIdleThreshold                  = "idle.threshold"
+	KeySleepDetection                 = "sleep.detection"
+	KeyMaxSession                     = "max_session"
 )
 
+// RateKeyPrefix prefixes the per-project hourly rate keys, e.g. "rate.@work". Rate keys have no default and are not
+// listed by [ValidKeys].
+const RateKeyPrefix = "rate.@"
+
 // defaults is a map defining the default configuration values for specific keys used in the application.
 //
 // It contains predefined key-value pairs that act as fallback values when no explicit configuration is provided.
@@ -18,22 +56,85 @@ const (
 //
 // This map is consulted whenever retrieving or validating configuration data, ensuring consistent behavior across the application.
 var defaults = map[string]string{
-	KeyOutputFormat: "table",
-	KeyDataLocation: "~/.tally",
+	KeyOutputFormat:                   "table",
+	KeyDataLocation:                   "~/.tally",
+	KeyStartAutoStopPrevious:          "false",
+	KeyDeleteDefaultYes:               "false",
+	KeyDeleteRequireTypedConfirmation: "false",
+	KeyReportTagSeparator:             ":",
+	KeyReportRounding:                 "0",
+	KeyReportCurrency:                 "$",
+	KeyReportWeekStart:                "monday",
+	KeyReportDefaultPeriod:            "menu",
+	KeyTimezone:                       "",
+	KeyGoalDaily:                      "",
+	KeyGoalWeekly:                     "",
+	KeyIdleThreshold:                  "",
+	KeySleepDetection:                 "true",
+	KeyMaxSession:                     "",
+}
+
+// Source identifies where the effective value of a setting comes from.
+type Source string
+
+// SourceEnv, SourceStored, and SourceDefault are the sources of a setting, in order of precedence: an environment
+// variable (see [EnvVar]), a value stored with `tally config set`, and the value in [defaults].
+const (
+	SourceEnv     Source = "env"
+	SourceStored  Source = "stored"
+	SourceDefault Source = "default"
+)
+
+// Setting is the effective value of a configuration key and where it comes from.
+type Setting struct {
+	Value  string
+	Source Source
+}
+
+// EnvVar returns the environment variable that overrides key: "TALLY_" followed by the key in upper case, with every
+// character other than a letter or digit replaced by "_". For example, "output.format" becomes TALLY_OUTPUT_FORMAT.
+func EnvVar(key string) string {
+	var b strings.Builder
+	b.WriteString("TALLY_")
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// lookupEnv returns the value of the environment variable overriding key, and whether it is set. An empty value
+// counts as set, so optional settings such as goal.daily can be turned off for one run.
+//
+// Only keys in [defaults] can be overridden, except [KeyDataLocation]: the data directory is chosen before settings
+// can be read, and is overridden with TALLY_DATA_DIR instead.
+func lookupEnv(key string) (string, bool) {
+	if _, ok := defaults[key]; !ok || key == KeyDataLocation {
+		return "", false
+	}
+	return os.LookupEnv(EnvVar(key))
 }
 
 // Get retrieves the configuration value associated with the given key.
 //
-// If the key exists in the database, its value is returned. If the key does not exist in the database
-// but has a default value defined in the [defaults] map, the default value is returned instead.
+// The value is taken from the first of these that has one:
+//   - The environment variable named by [EnvVar], so scripts can override a setting without storing it.
+//   - The database. [KeyDataLocation] is always read from the default database (see [db.DataLocation]), whichever
+//     database is open.
+//   - The [defaults] map.
 //
 // - key: The configuration key to look up.
 //
-// Returns the value as a string if found. Returns an empty string and nil error if the key has no value
-// in the database but a default value exists. Returns an error if fetching from the database fails or
-// the key is entirely unknown.
+// Returns the value as a string if found. Returns an empty string and nil error if the key has no value anywhere.
+// Returns an error if fetching from the database fails.
 func Get(key string) (string, error) {
-	value, err := db.GetConfig(key)
+	if value, ok := lookupEnv(key); ok {
+		return value, nil
+	}
+	value, err := getStored(key)
 	if err != nil {
 		return "", err
 	}
@@ -45,40 +146,68 @@ func Get(key string) (string, error) {
 	return value, nil
 }
 
+// getStored returns the stored value of key, reading [KeyDataLocation] from the default database.
+func getStored(key string) (string, error) {
+	if key == KeyDataLocation {
+		return db.DataLocation()
+	}
+	return db.GetConfig(key)
+}
+
 // Set updates the configuration by saving the provided key-value pair persistently.
 //
 // The function stores the key-value pair in the application's configuration storage. If the key already exists,
 // its value will be replaced. Invalid key or value handling is expected to be done prior to calling this function.
 //
+// [KeyDataLocation] is stored in the default database (see [db.SetDataLocation]), where it is read from, so it can be
+// changed again, or reverted, while another data directory is in use.
+//
 // Errors may occur under the following circumstances:
 //   - If there is an issue with the underlying storage operation.
 //   - If the database connection [DB] is unavailable.
 //
 // Returns an error if the operation fails.
 func Set(key, value string) error {
+	if key == KeyDataLocation {
+		return db.SetDataLocation(value)
+	}
 	return db.SetConfig(key, value)
 }
 
-// List retrieves a combined map of configuration settings by merging stored and default values.
+// List retrieves the effective value of every configuration setting, along with its source.
 //
-// It first fetches existing configurations using [db.ListConfig]. Default values from [defaults] are then layered on top,
-// with actual stored values taking precedence to ensure all configuration keys are represented.
+// It layers, from lowest to highest precedence, the [defaults], the stored values from [db.ListConfig], and the
+// environment variables named by [EnvVar], matching [Get], so all configuration keys are represented.
 //
-// Returns a map of the merged configurations with `stored` values overriding `defaults`. In case of an error during the
-// retrieval of stored configurations, it returns a non-nil error.
-func List() (map[string]string, error) {
+// Returns a map of the settings by key. In case of an error during the retrieval of stored configurations, it returns
+// a non-nil error.
+func List() (map[string]Setting, error) {
 	stored, err := db.ListConfig()
 	if err != nil {
 		return nil, err
 	}
+	location, err := db.DataLocation()
+	if err != nil {
+		return nil, err
+	}
+	// Any data.location in another data directory's database is never read, so only the default one is shown
+	stored[KeyDataLocation] = location
 
-	// Merge with defaults
-	result := make(map[string]string)
+	result := make(map[string]Setting)
 	for k, v := range defaults {
-		result[k] = v
+		result[k] = Setting{Value: v, Source: SourceDefault}
 	}
 	for k, v := range stored {
-		result[k] = v
+		// An empty stored value falls back to the default, as in Get
+		if _, ok := defaults[k]; ok && v == "" {
+			continue
+		}
+		result[k] = Setting{Value: v, Source: SourceStored}
+	}
+	for k := range defaults {
+		if v, ok := lookupEnv(k); ok {
+			result[k] = Setting{Value: v, Source: SourceEnv}
+		}
 	}
 	return result, nil
 }
@@ -136,8 +265,123 @@ func ValidKeys() []string {
 //
 // - key: A string representing the configuration key to verify.
 //
-// Returns true if the key exists in the defaults map, otherwise false.
+// Returns true if the key exists in the defaults map or is a rate key (see [IsRateKey]), otherwise false.
 func IsValidKey(key string) bool {
 	_, ok := defaults[key]
-	return ok
+	return ok || IsRateKey(key)
+}
+
+// RateKey returns the configuration key holding the hourly rate of the named project.
+func RateKey(project string) string {
+	return RateKeyPrefix + project
+}
+
+// IsRateKey reports whether key is a per-project rate key such as "rate.@work".
+func IsRateKey(key string) bool {
+	return strings.HasPrefix(key, RateKeyPrefix) && len(key) > len(RateKeyPrefix)
+}
+
+// ResolveRateKey returns the rate key of the project that the project named in key is an alias of, or key itself if
+// it does not name an alias, so "rate.@w" and "rate.@work" are the same setting when w is an alias of work.
+//
+// Returns an error if the alias cannot be looked up.
+func ResolveRateKey(key string) (string, error) {
+	name, err := db.ResolveProjectAlias(strings.TrimPrefix(key, RateKeyPrefix))
+	if err != nil {
+		return "", err
+	}
+	return RateKey(name), nil
+}
+
+// Rates returns the configured hourly rate of every project that has one, keyed by project name.
+//
+// Rates that fail to parse as numbers are skipped; they are validated when set, so this only happens if the database
+// was edited by hand.
+//
+// Returns an error if the stored configuration cannot be read.
+func Rates() (map[string]float64, error) {
+	stored, err := db.ListConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64)
+	for key, value := range stored {
+		if !IsRateKey(key) {
+			continue
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		rates[strings.TrimPrefix(key, RateKeyPrefix)] = rate
+	}
+	return rates, nil
+}
+
+// IdleThreshold returns the duration configured by [KeyIdleThreshold]. Zero means idle detection is off.
+//
+// Returns an error if the configuration cannot be read or the value is not a valid duration.
+func IdleThreshold() (time.Duration, error) {
+	return getOptionalDuration(KeyIdleThreshold)
+}
+
+// MaxSession returns the duration configured by [KeyMaxSession]. Zero means the check is off.
+//
+// Returns an error if the configuration cannot be read or the value is not a valid duration.
+func MaxSession() (time.Duration, error) {
+	return getOptionalDuration(KeyMaxSession)
+}
+
+// getOptionalDuration reads key as a non-negative duration such as "15m", where an empty value or "0" means off and
+// is returned as zero.
+func getOptionalDuration(key string) (time.Duration, error) {
+	value, err := Get(key)
+	if err != nil || value == "" || value == "0" {
+		return 0, err
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s: %q (use a duration like 15m)", key, value)
+	}
+	return d, nil
+}
+
+// WeekStart returns the configured first day of the week: [time.Sunday] when [KeyReportWeekStart] is "sunday", and
+// [time.Monday] otherwise.
+//
+// Returns an error if the configuration cannot be read.
+func WeekStart() (time.Weekday, error) {
+	value, err := Get(KeyReportWeekStart)
+	if err != nil {
+		return time.Monday, err
+	}
+	if value == "sunday" {
+		return time.Sunday, nil
+	}
+	return time.Monday, nil
+}
+
+// Location returns the time zone named by [KeyTimezone], such as "America/New_York", or [time.Local] when the setting
+// is empty.
+//
+// Returns an error naming where the setting came from if the zone is unknown, so a mistyped TALLY_TIMEZONE is not
+// silently replaced by the system zone.
+func Location() (*time.Location, error) {
+	name, err := Get(KeyTimezone)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		source := "the timezone setting"
+		if _, ok := lookupEnv(KeyTimezone); ok {
+			source = EnvVar(KeyTimezone)
+		}
+		return nil, fmt.Errorf("unknown time zone %q in %s (use an IANA name like America/New_York)", name, source)
+	}
+	return loc, nil
 }
@@ -1,9 +1,15 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -37,8 +43,8 @@ CREATE TABLE IF NOT EXISTS entry_tags (
     entry_id TEXT,
     tag_id TEXT,
     PRIMARY KEY (entry_id, tag_id),
-    FOREIGN KEY (entry_id) REFERENCES entries(id),
-    FOREIGN KEY (tag_id) REFERENCES tags(id)
+    FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
 );
 
 CREATE TABLE IF NOT EXISTS pauses (
@@ -47,9 +53,14 @@ CREATE TABLE IF NOT EXISTS pauses (
     pause_time DATETIME NOT NULL,
     resume_time DATETIME,
     reason TEXT DEFAULT 'Manual',
-    FOREIGN KEY (entry_id) REFERENCES entries(id)
+    FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE
 );
 
+CREATE INDEX IF NOT EXISTS idx_entries_start_time ON entries(start_time);
+CREATE INDEX IF NOT EXISTS idx_entries_project_id ON entries(project_id);
+CREATE INDEX IF NOT EXISTS idx_entry_tags_tag_id ON entry_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_pauses_entry_id ON pauses(entry_id);
+
 CREATE TABLE IF NOT EXISTS config (
     key TEXT PRIMARY KEY,
     value TEXT
@@ -59,12 +70,61 @@ CREATE TABLE IF NOT EXISTS activity (
     id INTEGER PRIMARY KEY CHECK (id = 1),
     last_activity DATETIME
 );
+
+CREATE TABLE IF NOT EXISTS templates (
+    name TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    title TEXT,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS template_tags (
+    template_name TEXT,
+    tag_id TEXT,
+    PRIMARY KEY (template_name, tag_id),
+    FOREIGN KEY (template_name) REFERENCES templates(name) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS project_aliases (
+    alias TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
 `
 
-// GetDataDir returns the path to the application's data directory within the user's home directory.
+// DataDirOverride, when not empty, is used as the data directory instead of the data.location setting. It is set from
+// the --data-dir flag or the TALLY_DATA_DIR environment variable before [Init] is called.
+var DataDirOverride string
+
+// Location is the time zone that times read from the database are converted to, and that [Now] reports the time in.
+// It defaults to the system zone, and the CLI sets it from the timezone setting after [Init]. Times are always stored
+// in UTC, so it only affects how they are read, entered, and grouped into days, never what is stored.
+//
+// It is the single place the configured zone is kept; [time.Local] is never changed.
+var Location = time.Local
+
+// Now returns the current time in [Location].
+func Now() time.Time {
+	return time.Now().In(Location)
+}
+
+// dataLocationKey mirrors config.KeyDataLocation, and rateKeyPrefix mirrors config.RateKeyPrefix, which this package
+// cannot import.
+const (
+	dataLocationKey = "data.location"
+	rateKeyPrefix   = "rate.@"
+)
+
+// GetDataDir returns the path to the application's data directory.
+//
+// The directory is chosen in this order:
+//   - [DataDirOverride], if set.
+//   - The data.location setting stored in the default database at ~/.tally/tally.db, if it exists and can be read.
+//   - ~/.tally.
 //
-// It determines the user's home directory using [os.UserHomeDir] and appends a folder named ".tally" to it.
-// If the home directory cannot be determined, it returns an empty string and an error.
+// Because settings live in the database, data.location is always read from the default location. A leading "~" in
+// either path is expanded to the user's home directory.
 //
 // Returns:
 //   - A string representing the data directory path.
@@ -74,7 +134,99 @@ func GetDataDir() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".tally"), nil
+	if DataDirOverride != "" {
+		return expandHome(DataDirOverride, homeDir), nil
+	}
+
+	defaultDir := filepath.Join(homeDir, ".tally")
+	if location := readDataLocation(filepath.Join(defaultDir, "tally.db")); location != "" {
+		return expandHome(location, homeDir), nil
+	}
+	return defaultDir, nil
+}
+
+// defaultDBPath returns the path of the default database, ~/.tally/tally.db, which holds the data.location setting.
+func defaultDBPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".tally", "tally.db"), nil
+}
+
+// DataLocation returns the data.location setting stored in the default database at ~/.tally/tally.db, or an empty
+// string if it has none.
+//
+// The setting is read from the default database whichever database is open, because that is where [GetDataDir] looks
+// for it.
+//
+// Returns an error if the user's home directory cannot be determined.
+func DataLocation() (string, error) {
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		return "", err
+	}
+	return readDataLocation(dbPath), nil
+}
+
+// SetDataLocation stores the data.location setting in the default database at ~/.tally/tally.db, creating the
+// database if it does not exist yet. Like [DataLocation], it does so whichever database is open.
+//
+// Returns an error if the default database cannot be created or written.
+func SetDataLocation(location string) error {
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return err
+	}
+	pragmas, err := connectionPragmas()
+	if err != nil {
+		return err
+	}
+	conn, err := sql.Open("sqlite", dbPath+"?_time_format=sqlite"+pragmas)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The rest of the schema is created when the database is first opened with Init
+	if _, err := conn.Exec("CREATE TABLE IF NOT EXISTS config (key TEXT PRIMARY KEY, value TEXT)"); err != nil {
+		return err
+	}
+	_, err = conn.Exec("INSERT OR REPLACE INTO config (key, value) VALUES (?, ?)", dataLocationKey, location)
+	return err
+}
+
+// readDataLocation returns the data.location setting stored in the database at dbPath, or an empty string if the
+// database does not exist, has no such setting, or cannot be read.
+func readDataLocation(dbPath string) string {
+	if _, err := os.Stat(dbPath); err != nil {
+		return ""
+	}
+	conn, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+
+	var location string
+	if err := conn.QueryRow("SELECT value FROM config WHERE key = ?", dataLocationKey).Scan(&location); err != nil {
+		return ""
+	}
+	return location
+}
+
+// expandHome replaces a leading "~" in path with homeDir.
+func expandHome(path, homeDir string) string {
+	if path == "~" {
+		return homeDir
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(homeDir, rest)
+	}
+	return path
 }
 
 // Init initializes the database and ensures the required schema is present.
@@ -84,6 +236,14 @@ func GetDataDir() (string, error) {
 //
 // - If the `activity` table is empty, it inserts a default row.
 // - Migrations are executed but may silently ignore errors related to redundant changes.
+// - Timestamps written by older versions are rewritten once in UTC (see [normalizeTimestamps]).
+// - Pauses, tag links, and templates are rebuilt once to be deleted with what they refer to (see [addCascadeDeletes]).
+//
+// Foreign keys are enforced on every connection with `PRAGMA foreign_keys`, which SQLite leaves off by default. The
+// journal mode and busy timeout are set on every connection as well (see [connectionPragmas]).
+//
+// All timestamps are stored in UTC using a fixed, sortable format, so durations and range comparisons are unaffected
+// by daylight-saving-time changes. They are converted to [Location] when read.
 //
 // Returns an error if directory creation or database initialization fails. Silent errors may occur for migrations.
 func Init() error {
@@ -97,7 +257,11 @@ func Init() error {
 	}
 
 	dbPath := filepath.Join(dataDir, "tally.db")
-	DB, err = sql.Open("sqlite", dbPath)
+	pragmas, err := connectionPragmas()
+	if err != nil {
+		return err
+	}
+	DB, err = sql.Open("sqlite", dbPath+"?_time_format=sqlite"+pragmas)
 	if err != nil {
 		return err
 	}
@@ -111,17 +275,80 @@ func Init() error {
 	migrations := []string{
 		// Add reason column to pauses table
 		`ALTER TABLE pauses ADD COLUMN reason TEXT DEFAULT 'Manual'`,
+		// Record the ID an imported entry had in its source tool, so re-imports can skip it
+		`ALTER TABLE entries ADD COLUMN source_id TEXT`,
+		`CREATE INDEX IF NOT EXISTS idx_entries_source_id ON entries(source_id)`,
+		// Add a free-form note to entries, for detail that doesn't fit the title
+		`ALTER TABLE entries ADD COLUMN note TEXT`,
+		// Name the timer an entry runs on, so several timers can run at once; empty is the default timer
+		`ALTER TABLE entries ADD COLUMN timer TEXT`,
+		// Hide old projects from listings and completion without losing their history
+		`ALTER TABLE projects ADD COLUMN archived BOOLEAN NOT NULL DEFAULT 0`,
+		// Mark entries as billable or not, for invoicing mixed work
+		`ALTER TABLE entries ADD COLUMN billable BOOLEAN NOT NULL DEFAULT 1`,
+		// Record when an entry was invoiced, so reports can leave out time that has already been billed
+		`ALTER TABLE entries ADD COLUMN billed_at DATETIME`,
+		// Estimate how long an entry will take, in seconds, to compare with the time actually spent
+		`ALTER TABLE entries ADD COLUMN estimate INTEGER`,
+		// Plan when a running entry should stop, so the next command can stop it if it is still running by then
+		`ALTER TABLE entries ADD COLUMN planned_end DATETIME`,
 	}
 
 	for _, m := range migrations {
 		DB.Exec(m) // Ignore errors (column may already exist)
 	}
 
+	if err := normalizeTimestamps(); err != nil {
+		return err
+	}
+	if err := addCascadeDeletes(); err != nil {
+		return fmt.Errorf("failed to migrate foreign keys: %w", err)
+	}
+
 	// Initialize activity table with a single row
 	_, err = DB.Exec(`INSERT OR IGNORE INTO activity (id, last_activity) VALUES (1, datetime('now'))`)
 	return err
 }
 
+// Journal mode and busy timeout applied to every connection unless overridden by the TALLY_JOURNAL_MODE and
+// TALLY_BUSY_TIMEOUT environment variables. WAL lets a reader such as `status --watch` run alongside a writer, and the
+// timeout makes a writer wait for a lock instead of failing with "database is locked".
+const (
+	defaultJournalMode = "WAL"
+	defaultBusyTimeout = 5000 // milliseconds
+)
+
+// journalModes lists the values SQLite accepts for `PRAGMA journal_mode`.
+var journalModes = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+
+// connectionPragmas returns the DSN parameters that set the busy timeout and journal mode of each connection.
+//
+// The busy timeout, in milliseconds, is read from TALLY_BUSY_TIMEOUT and the journal mode from TALLY_JOURNAL_MODE;
+// [defaultBusyTimeout] and [defaultJournalMode] are used when they are unset. The timeout comes first, so that
+// switching the journal mode also waits for a lock.
+//
+// Returns an error if either variable holds an invalid value.
+func connectionPragmas() (string, error) {
+	timeout := defaultBusyTimeout
+	if v := os.Getenv("TALLY_BUSY_TIMEOUT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid TALLY_BUSY_TIMEOUT: %s (use milliseconds, e.g. 5000)", v)
+		}
+		timeout = n
+	}
+
+	mode := defaultJournalMode
+	if v := os.Getenv("TALLY_JOURNAL_MODE"); v != "" {
+		mode = strings.ToUpper(v)
+		if !slices.Contains(journalModes, mode) {
+			return "", fmt.Errorf("invalid TALLY_JOURNAL_MODE: %s (use one of %s)", v, strings.Join(journalModes, ", "))
+		}
+	}
+
+	return fmt.Sprintf("&_pragma=busy_timeout(%d)&_pragma=journal_mode(%s)&_pragma=foreign_keys(1)", timeout, mode), nil
+}
+
 // Close safely terminates the database connection held by DB.
 //
 // If DB is already nil, it does nothing and returns nil. Otherwise, it calls DB.Close() and returns any error that occurs.
@@ -131,3 +358,288 @@ func Close() error {
 	}
 	return nil
 }
+
+// storedTimeFormat is the layout the driver writes timestamps in, as selected by `_time_format=sqlite`.
+const storedTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// timestampColumns lists every DATETIME column as table/column pairs, keyed by the table's primary key.
+var timestampColumns = [][2]string{
+	{"projects", "created_at"},
+	{"tags", "created_at"},
+	{"entries", "start_time"},
+	{"entries", "end_time"},
+	{"pauses", "pause_time"},
+	{"pauses", "resume_time"},
+}
+
+// normalizeTimestamps rewrites every stored timestamp in UTC, once per database.
+//
+// Older versions stored timestamps in the local time zone using [time.Time.String], which makes string comparisons in
+// SQL unreliable across offset changes. The migration is tracked with `PRAGMA user_version` and runs in a single
+// transaction.
+//
+// Returns an error if reading or rewriting any timestamp fails.
+func normalizeTimestamps() error {
+	var version int
+	if err := DB.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version >= 1 {
+		return nil
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, tc := range timestampColumns {
+		table, column := tc[0], tc[1]
+
+		rows, err := tx.Query("SELECT id, " + column + " FROM " + table + " WHERE " + column + " IS NOT NULL")
+		if err != nil {
+			return err
+		}
+
+		values := make(map[string]time.Time)
+		for rows.Next() {
+			var id string
+			var t time.Time
+			if err := rows.Scan(&id, &t); err != nil {
+				rows.Close()
+				return err
+			}
+			values[id] = t
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for id, t := range values {
+			if _, err := tx.Exec("UPDATE "+table+" SET "+column+" = ? WHERE id = ?", t.UTC(), id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.Exec("PRAGMA user_version = 1"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// cascadeTables holds the definitions of the tables rebuilt by [addCascadeDeletes], with the columns copied from the
+// old table, the statements that recreate its indexes, and the `PRAGMA user_version` the rebuild brings the database
+// to.
+var cascadeTables = []struct {
+	name, create, columns string
+	indexes               []string
+	version               int
+}{
+	{
+		version: 2,
+		name:    "entry_tags",
+		create: `CREATE TABLE entry_tags_new (
+			entry_id TEXT,
+			tag_id TEXT,
+			PRIMARY KEY (entry_id, tag_id),
+			FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		)`,
+		columns: "entry_id, tag_id",
+		indexes: []string{"CREATE INDEX IF NOT EXISTS idx_entry_tags_tag_id ON entry_tags(tag_id)"},
+	},
+	{
+		version: 2,
+		name:    "pauses",
+		create: `CREATE TABLE pauses_new (
+			id TEXT PRIMARY KEY,
+			entry_id TEXT NOT NULL,
+			pause_time DATETIME NOT NULL,
+			resume_time DATETIME,
+			reason TEXT DEFAULT 'Manual',
+			FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE CASCADE
+		)`,
+		columns: "id, entry_id, pause_time, resume_time, reason",
+		indexes: []string{"CREATE INDEX IF NOT EXISTS idx_pauses_entry_id ON pauses(entry_id)"},
+	},
+	{
+		version: 3,
+		name:    "templates",
+		create: `CREATE TABLE templates_new (
+			name TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			title TEXT,
+			FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+		)`,
+		columns: "name, project_id, title",
+	},
+	{
+		version: 3,
+		name:    "template_tags",
+		create: `CREATE TABLE template_tags_new (
+			template_name TEXT,
+			tag_id TEXT,
+			PRIMARY KEY (template_name, tag_id),
+			FOREIGN KEY (template_name) REFERENCES templates(name) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		)`,
+		columns: "template_name, tag_id",
+	},
+}
+
+// addCascadeDeletes rebuilds the tables in [cascadeTables] with ON DELETE CASCADE foreign keys, once per database.
+//
+// SQLite cannot alter a foreign key in place, so each table is copied into a new one that replaces it, following the
+// procedure in https://www.sqlite.org/lang_altertable.html. Foreign keys are turned off on a dedicated connection
+// while the tables are swapped, and rows that already reference deleted rows are copied as they are; they can be found
+// and removed with `tally doctor`. The migration is tracked with `PRAGMA user_version`, so that only the tables added
+// since the last run are rebuilt, and runs in a single transaction.
+//
+// Returns an error if rebuilding any table fails.
+func addCascadeDeletes() error {
+	var version int
+	if err := DB.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	target := cascadeTables[len(cascadeTables)-1].version
+	if version >= target {
+		return nil
+	}
+
+	ctx := context.Background()
+	conn, err := DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The pragma has no effect inside a transaction, so it is set on the connection first
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range cascadeTables {
+		if t.version <= version {
+			continue
+		}
+		statements := []string{
+			t.create,
+			"INSERT INTO " + t.name + "_new (" + t.columns + ") SELECT " + t.columns + " FROM " + t.name,
+			"DROP TABLE " + t.name,
+			"ALTER TABLE " + t.name + "_new RENAME TO " + t.name,
+		}
+		for _, stmt := range append(statements, t.indexes...) {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", target)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// localTime is a [sql.Scanner] that stores a scanned timestamp in dst, converted to [Location].
+type localTime struct {
+	dst *time.Time
+}
+
+// Scan implements [sql.Scanner].
+func (lt localTime) Scan(src any) error {
+	t, err := scanTime(src)
+	if err != nil {
+		return err
+	}
+	*lt.dst = t.In(Location)
+	return nil
+}
+
+// nullLocalTime is a [sql.Scanner] like [localTime] for nullable columns. NULL is stored in dst as a nil pointer.
+//
+// Unlike [sql.NullTime], it also accepts timestamps returned as text, which is how SQLite returns the results of
+// aggregates such as MAX(start_time).
+type nullLocalTime struct {
+	dst **time.Time
+}
+
+// Scan implements [sql.Scanner].
+func (nt nullLocalTime) Scan(src any) error {
+	if src == nil {
+		*nt.dst = nil
+		return nil
+	}
+	t, err := scanTime(src)
+	if err != nil {
+		return err
+	}
+	local := t.In(Location)
+	*nt.dst = &local
+	return nil
+}
+
+// nullDuration is a [sql.Scanner] for nullable durations stored as whole seconds. NULL is stored in dst as a nil
+// pointer.
+type nullDuration struct {
+	dst **time.Duration
+}
+
+// Scan implements [sql.Scanner].
+func (nd nullDuration) Scan(src any) error {
+	if src == nil {
+		*nd.dst = nil
+		return nil
+	}
+	seconds, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a duration", src)
+	}
+	d := time.Duration(seconds) * time.Second
+	*nd.dst = &d
+	return nil
+}
+
+// scanTime converts a scanned timestamp value, either a [time.Time] or text in the stored format, to a [time.Time].
+func scanTime(src any) (time.Time, error) {
+	switch v := src.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(storedTimeFormat, v)
+	case []byte:
+		return time.Parse(storedTimeFormat, string(v))
+	default:
+		return time.Time{}, fmt.Errorf("cannot scan %T into a timestamp", src)
+	}
+}
+
+// localPtr returns a pointer to the value of t in [Location], or nil if t is NULL.
+func localPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	local := t.Time.In(Location)
+	return &local
+}
+
+// utcPtr returns a pointer to the UTC value of t for storage, or nil if t is nil.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// openTestDB initializes [DB] in a temporary data directory that is removed when the test ends.
+func openTestDB(t testing.TB) {
+	t.Helper()
+	DataDirOverride = t.TempDir()
+	if err := Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		Close()
+		DB = nil
+		DataDirOverride = ""
+	})
+}
+
+func TestForeignKeys(t *testing.T) {
+	openTestDB(t)
+
+	project, err := GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := GetOrCreateTag("meeting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-time.Hour)
+	entry, err := CreateCompletedEntry(project.ID, "Standup", []string{tag.ID}, start, start.Add(30*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resume := start.Add(10 * time.Minute)
+	if _, err := CreatePause(entry.ID, start.Add(5*time.Minute), &resume, "Manual"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("insert with unknown entry is refused", func(t *testing.T) {
+		_, err := DB.Exec("INSERT INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", "bogus", tag.ID)
+		if err == nil {
+			t.Fatal("insert into entry_tags succeeded, want a foreign key error")
+		}
+	})
+
+	t.Run("deleting an entry removes its pauses and tags", func(t *testing.T) {
+		if err := DeleteEntry(entry.ID); err != nil {
+			t.Fatal(err)
+		}
+		assertCount(t, "SELECT COUNT(*) FROM pauses WHERE entry_id = ?", entry.ID, 0)
+		assertCount(t, "SELECT COUNT(*) FROM entry_tags WHERE entry_id = ?", entry.ID, 0)
+	})
+
+	t.Run("deleting a project or tag removes its templates", func(t *testing.T) {
+		other, err := GetOrCreateTag("client")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := SaveTemplate("standup", project.ID, "Standup", []string{tag.ID, other.ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := DB.Exec("DELETE FROM tags WHERE id = ?", other.ID); err != nil {
+			t.Fatalf("deleting a tag used by a template: %v", err)
+		}
+		assertCount(t, "SELECT COUNT(*) FROM template_tags WHERE tag_id = ?", other.ID, 0)
+
+		if _, err := DB.Exec("DELETE FROM projects WHERE id = ?", project.ID); err != nil {
+			t.Fatalf("deleting a project used by a template: %v", err)
+		}
+		assertCount(t, "SELECT COUNT(*) FROM templates WHERE name = ?", "standup", 0)
+		assertCount(t, "SELECT COUNT(*) FROM template_tags WHERE template_name = ?", "standup", 0)
+	})
+}
+
+func TestAddCascadeDeletesUpgradesTemplates(t *testing.T) {
+	openTestDB(t)
+
+	// Recreate the template tables as databases at version 2 have them, without cascading deletes
+	for _, stmt := range []string{
+		"DROP TABLE template_tags",
+		"DROP TABLE templates",
+		`CREATE TABLE templates (
+			name TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			title TEXT,
+			FOREIGN KEY (project_id) REFERENCES projects(id)
+		)`,
+		`CREATE TABLE template_tags (
+			template_name TEXT,
+			tag_id TEXT,
+			PRIMARY KEY (template_name, tag_id),
+			FOREIGN KEY (template_name) REFERENCES templates(name),
+			FOREIGN KEY (tag_id) REFERENCES tags(id)
+		)`,
+		"PRAGMA user_version = 2",
+	} {
+		if _, err := DB.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	project, err := GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveTemplate("standup", project.ID, "Standup", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addCascadeDeletes(); err != nil {
+		t.Fatalf("addCascadeDeletes() error = %v", err)
+	}
+
+	if _, err := GetTemplate("standup"); err != nil {
+		t.Errorf("template was not kept: %v", err)
+	}
+	for _, table := range []string{"templates", "template_tags"} {
+		rows, err := DB.Query("SELECT \"table\", on_delete FROM pragma_foreign_key_list(?)", table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var parent, onDelete string
+			if err := rows.Scan(&parent, &onDelete); err != nil {
+				t.Fatal(err)
+			}
+			if onDelete != "CASCADE" {
+				t.Errorf("%s -> %s: ON DELETE %s, want CASCADE", table, parent, onDelete)
+			}
+		}
+		rows.Close()
+	}
+}
+
+// assertCount fails t unless query, run with arg, returns want.
+func assertCount(t *testing.T, query, arg string, want int) {
+	t.Helper()
+	var got int
+	if err := DB.QueryRow(query, arg).Scan(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("%s [%s] = %d, want %d", query, arg, got, want)
+	}
+}
+
+// countingConnector opens connections to dsn with driver, counting every statement they prepare in queries.
+type countingConnector struct {
+	driver  driver.Driver
+	dsn     string
+	queries *atomic.Int64
+}
+
+func (c countingConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return countingConn{conn, c.queries}, nil
+}
+
+func (c countingConnector) Driver() driver.Driver { return c.driver }
+
+// countingConn hides the optional interfaces of the wrapped connection, so [database/sql] prepares every statement.
+type countingConn struct {
+	driver.Conn
+	queries *atomic.Int64
+}
+
+func (c countingConn) Prepare(query string) (driver.Stmt, error) {
+	c.queries.Add(1)
+	return c.Conn.Prepare(query)
+}
+
+// countQueries replaces [DB], opened by [openTestDB], with a connection pool to the same database that counts the
+// statements it runs, and returns the counter.
+func countQueries(t testing.TB) *atomic.Int64 {
+	t.Helper()
+	pragmas, err := connectionPragmas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := new(atomic.Int64)
+	counted := sql.OpenDB(countingConnector{
+		driver:  DB.Driver(),
+		dsn:     filepath.Join(DataDirOverride, "tally.db") + "?_time_format=sqlite" + pragmas,
+		queries: queries,
+	})
+	original := DB
+	DB = counted
+	t.Cleanup(func() {
+		counted.Close()
+		DB = original
+	})
+	return queries
+}
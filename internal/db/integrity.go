@@ -0,0 +1,69 @@
+package db
+
+// IntegrityCheck is a database consistency check run by `tally doctor`: a kind of row that should not exist, with a
+// query to count such rows and a statement to repair them.
+type IntegrityCheck struct {
+	// Problem describes the rows found, e.g. "pauses of deleted entries".
+	Problem string
+	// Repair describes what [IntegrityCheck.Fix] does to them.
+	Repair string
+
+	count string
+	fix   string
+}
+
+// IntegrityChecks lists the checks run by `tally doctor`, in the order their fixes should be applied.
+//
+// Entries ending before they start are clamped before dangling pauses are closed, so those pauses are closed at the
+// repaired end time.
+var IntegrityChecks = []IntegrityCheck{
+	{
+		Problem: "pauses of deleted entries",
+		Repair:  "deleted",
+		count:   "SELECT COUNT(*) FROM pauses WHERE entry_id NOT IN (SELECT id FROM entries)",
+		fix:     "DELETE FROM pauses WHERE entry_id NOT IN (SELECT id FROM entries)",
+	},
+	{
+		Problem: "tag links to deleted entries or tags",
+		Repair:  "deleted",
+		count: `SELECT COUNT(*) FROM entry_tags
+			WHERE entry_id NOT IN (SELECT id FROM entries) OR tag_id NOT IN (SELECT id FROM tags)`,
+		fix: `DELETE FROM entry_tags
+			WHERE entry_id NOT IN (SELECT id FROM entries) OR tag_id NOT IN (SELECT id FROM tags)`,
+	},
+	{
+		Problem: "entries ending before they start",
+		Repair:  "end time set to the start time",
+		count:   "SELECT COUNT(*) FROM entries WHERE end_time IS NOT NULL AND end_time < start_time",
+		fix:     "UPDATE entries SET end_time = start_time WHERE end_time IS NOT NULL AND end_time < start_time",
+	},
+	{
+		Problem: "open pauses on stopped entries",
+		Repair:  "closed at the entry's end time",
+		count: `SELECT COUNT(*) FROM pauses
+			WHERE resume_time IS NULL
+			AND entry_id IN (SELECT id FROM entries WHERE status = 'stopped' AND end_time IS NOT NULL)`,
+		fix: `UPDATE pauses SET resume_time = (SELECT end_time FROM entries WHERE entries.id = pauses.entry_id)
+			WHERE resume_time IS NULL
+			AND entry_id IN (SELECT id FROM entries WHERE status = 'stopped' AND end_time IS NOT NULL)`,
+	},
+}
+
+// Count returns the number of rows with the check's problem, or an error if the query fails.
+func (c IntegrityCheck) Count() (int, error) {
+	var n int
+	err := DB.QueryRow(c.count).Scan(&n)
+	return n, err
+}
+
+// Fix repairs the rows with the check's problem as described by [IntegrityCheck.Repair].
+//
+// Returns the number of rows repaired, or an error if the statement fails.
+func (c IntegrityCheck) Fix() (int, error) {
+	result, err := DB.Exec(c.fix)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
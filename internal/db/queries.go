@@ -2,6 +2,9 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/thinktide/tally/internal/model"
@@ -11,18 +14,30 @@ import (
 
 // GetOrCreateProject retrieves an existing project by its name or creates a new one if no such project exists.
 //
-// If a project with the specified name is found in the database, it is returned. If no project is found, a new project
-// is created with a unique ID and current timestamp, then inserted into the database.
+// A name that is a project alias is resolved first with [ResolveProjectAlias], so an alias never becomes a project of
+// its own. If a project with the resulting name is found in the database, it is returned. If no project is found, a
+// new project is created with a unique ID and current timestamp, then inserted into the database.
 //
-// - name: The name of the project to retrieve or create.
+// The insert does nothing if the name was taken in the meantime, for example by a concurrent `tally start`, and the
+// project is selected again, so both callers get the same row instead of one failing the UNIQUE constraint.
+//
+// - name: The name or alias of the project to retrieve or create.
 //
 // Returns a pointer to a [model.Project] representing the retrieved or newly created project.
 // Returns an error if a database operation fails or another unexpected issue occurs.
 func GetOrCreateProject(name string) (*model.Project, error) {
+	name, err := ResolveProjectAlias(name)
+	if err != nil {
+		return nil, err
+	}
+
 	// Try to get existing project
 	var p model.Project
-	err := DB.QueryRow("SELECT id, name, created_at FROM projects WHERE name = ?", name).
-		Scan(&p.ID, &p.Name, &p.CreatedAt)
+	selectProject := func() error {
+		return DB.QueryRow("SELECT id, name, archived, created_at FROM projects WHERE name = ?", name).
+			Scan(&p.ID, &p.Name, &p.Archived, localTime{&p.CreatedAt})
+	}
+	err = selectProject()
 	if err == nil {
 		return &p, nil
 	}
@@ -30,19 +45,16 @@ func GetOrCreateProject(name string) (*model.Project, error) {
 		return nil, err
 	}
 
-	// Create new project with ULID
-	id := model.NewULID()
-	now := time.Now()
-	_, err = DB.Exec("INSERT INTO projects (id, name, created_at) VALUES (?, ?, ?)", id, name, now)
+	// Create new project with ULID, unless another process just did
+	_, err = DB.Exec("INSERT INTO projects (id, name, created_at) VALUES (?, ?, ?) ON CONFLICT(name) DO NOTHING",
+		model.NewULID(), name, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
-
-	return &model.Project{
-		ID:        id,
-		Name:      name,
-		CreatedAt: now,
-	}, nil
+	if err := selectProject(); err != nil {
+		return nil, err
+	}
+	return &p, nil
 }
 
 // GetProjectByID retrieves a [model.Project] by its unique identifier.
@@ -55,22 +67,308 @@ func GetOrCreateProject(name string) (*model.Project, error) {
 //   - Any other errors that occur during the database query execution.
 func GetProjectByID(id string) (*model.Project, error) {
 	var p model.Project
-	err := DB.QueryRow("SELECT id, name, created_at FROM projects WHERE id = ?", id).
-		Scan(&p.ID, &p.Name, &p.CreatedAt)
+	err := DB.QueryRow("SELECT id, name, archived, created_at FROM projects WHERE id = ?", id).
+		Scan(&p.ID, &p.Name, &p.Archived, localTime{&p.CreatedAt})
 	if err != nil {
 		return nil, err
 	}
 	return &p, nil
 }
 
+// ProjectActivity describes a project along with how much it has been used.
+//
+// The embedded CreatedAt is when the project was first seen. LastActivity is the start time of its most recent entry,
+// or nil if the project has no entries.
+type ProjectActivity struct {
+	model.Project
+	Entries      int        `json:"entries"`
+	LastActivity *time.Time `json:"last_activity,omitempty"`
+}
+
+// ListProjectsWithActivity retrieves all projects with their entry counts and most recent activity.
+//
+// Projects are ordered by name. Archived projects are left out unless includeArchived is set. The result is computed
+// with a single query joining projects to entries.
+//
+// Returns a slice of [ProjectActivity], or an error if the query fails.
+func ListProjectsWithActivity(includeArchived bool) ([]ProjectActivity, error) {
+	rows, err := DB.Query(`
+		SELECT p.id, p.name, p.archived, p.created_at, COUNT(e.id), MAX(e.start_time)
+		FROM projects p
+		LEFT JOIN entries e ON e.project_id = p.id
+		WHERE ? OR NOT p.archived
+		GROUP BY p.id
+		ORDER BY p.name`, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []ProjectActivity
+	for rows.Next() {
+		var p ProjectActivity
+		if err := rows.Scan(&p.ID, &p.Name, &p.Archived, localTime{&p.CreatedAt}, &p.Entries, nullLocalTime{&p.LastActivity}); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+// SetProjectArchived archives or unarchives the project with the given ID.
+//
+// Archived projects are hidden from project listings, `tally log`, and completion, but their entries still count in
+// reports.
+func SetProjectArchived(id string, archived bool) error {
+	_, err := DB.Exec("UPDATE projects SET archived = ? WHERE id = ?", archived, id)
+	return err
+}
+
+// MergeProjects moves every entry, template, and alias of the source project to the destination project and deletes the source.
+//
+// The source's hourly rate moves to the destination if the destination has none; otherwise the destination keeps its
+// own and the source's is removed.
+//
+// All steps run in one transaction, so the source is only deleted once it is empty.
+//
+// Returns the number of entries moved, or an error if sourceID equals destID or a database operation fails.
+func MergeProjects(sourceID, destID string) (int, error) {
+	if sourceID == destID {
+		return 0, fmt.Errorf("cannot merge a project into itself")
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE entries SET project_id = ? WHERE project_id = ?", destID, sourceID)
+	if err != nil {
+		return 0, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("UPDATE templates SET project_id = ? WHERE project_id = ?", destID, sourceID); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("UPDATE project_aliases SET project_id = ? WHERE project_id = ?", destID, sourceID); err != nil {
+		return 0, err
+	}
+
+	var sourceName, destName string
+	if err := tx.QueryRow("SELECT name FROM projects WHERE id = ?", sourceID).Scan(&sourceName); err != nil {
+		return 0, err
+	}
+	if err := tx.QueryRow("SELECT name FROM projects WHERE id = ?", destID).Scan(&destName); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("UPDATE OR IGNORE config SET key = ? WHERE key = ?", rateKeyPrefix+destName, rateKeyPrefix+sourceName); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("DELETE FROM config WHERE key = ?", rateKeyPrefix+sourceName); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM projects WHERE id = ?", sourceID); err != nil {
+		return 0, err
+	}
+
+	return int(moved), tx.Commit()
+}
+
+// Project alias operations
+
+// ResolveProjectAlias returns the name of the project that name is an alias of, or name itself if it is not an alias.
+//
+// Returns an error if the database query fails.
+func ResolveProjectAlias(name string) (string, error) {
+	var project string
+	err := DB.QueryRow(`
+		SELECT p.name FROM project_aliases a JOIN projects p ON p.id = a.project_id
+		WHERE a.alias = ?`, name).Scan(&project)
+	if err == sql.ErrNoRows {
+		return name, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return project, nil
+}
+
+// SetProjectAlias makes alias resolve to the project with projectID, replacing any existing alias with that name.
+//
+// Returns an error if a project is named alias, since the alias would hide it, or if a database operation fails.
+func SetProjectAlias(alias, projectID string) error {
+	var count int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM projects WHERE name = ?", alias).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("project @%s already exists", alias)
+	}
+
+	_, err := DB.Exec(`
+		INSERT INTO project_aliases (alias, project_id) VALUES (?, ?)
+		ON CONFLICT(alias) DO UPDATE SET project_id = excluded.project_id`,
+		alias, projectID)
+	return err
+}
+
+// DeleteProjectAlias removes the alias with the given name. The project it resolved to is kept.
+//
+// Returns false if there is no such alias, or an error if the delete fails.
+func DeleteProjectAlias(alias string) (bool, error) {
+	result, err := DB.Exec("DELETE FROM project_aliases WHERE alias = ?", alias)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ListProjectAliases returns every project alias with the name of its project, ordered by alias.
+//
+// Returns an error if the database query fails.
+func ListProjectAliases() ([]model.ProjectAlias, error) {
+	rows, err := DB.Query(`
+		SELECT a.alias, p.name FROM project_aliases a JOIN projects p ON p.id = a.project_id
+		ORDER BY a.alias`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []model.ProjectAlias
+	for rows.Next() {
+		var a model.ProjectAlias
+		if err := rows.Scan(&a.Alias, &a.Project); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
 // Tag operations
 
+// TagUsage pairs a [model.Tag] with the number of entries it is attached to.
+type TagUsage struct {
+	model.Tag
+	Entries int `json:"entries"`
+}
+
+// ListTags retrieves all tags with the number of entries using each, ordered by name.
+//
+// Returns a slice of [TagUsage], or an error if the query fails.
+func ListTags() ([]TagUsage, error) {
+	rows, err := DB.Query(`
+		SELECT t.id, t.name, t.created_at, COUNT(et.entry_id)
+		FROM tags t
+		LEFT JOIN entry_tags et ON et.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []TagUsage
+	for rows.Next() {
+		var t TagUsage
+		if err := rows.Scan(&t.ID, &t.Name, localTime{&t.CreatedAt}, &t.Entries); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// DeleteTag removes the tag with the given id and detaches it from every entry and template. The entries and templates
+// themselves are kept.
+//
+// All deletions run in one transaction.
+//
+// Returns the number of entries the tag was removed from, or an error if a database operation fails.
+func DeleteTag(id string) (int, error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM entry_tags WHERE tag_id = ?", id)
+	if err != nil {
+		return 0, err
+	}
+	detached, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM template_tags WHERE tag_id = ?", id); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM tags WHERE id = ?", id); err != nil {
+		return 0, err
+	}
+
+	return int(detached), tx.Commit()
+}
+
+// AddTagToEntries attaches the tag identified by tagID to each of the given entries in a single transaction. Entries
+// that already have the tag are left unchanged.
+//
+// Returns the number of entries the tag was added to, or an error if any statement or the commit fails.
+func AddTagToEntries(tagID string, entryIDs []string) (int, error) {
+	return updateEntryTags("INSERT OR IGNORE INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", tagID, entryIDs)
+}
+
+// RemoveTagFromEntries detaches the tag identified by tagID from each of the given entries in a single transaction.
+// Entries without the tag are left unchanged.
+//
+// Returns the number of entries the tag was removed from, or an error if any statement or the commit fails.
+func RemoveTagFromEntries(tagID string, entryIDs []string) (int, error) {
+	return updateEntryTags("DELETE FROM entry_tags WHERE entry_id = ? AND tag_id = ?", tagID, entryIDs)
+}
+
+// updateEntryTags runs stmt, which takes an entry ID and a tag ID, once per entry in a transaction and returns the
+// total number of affected rows.
+func updateEntryTags(stmt, tagID string, entryIDs []string) (int, error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	changed := 0
+	for _, entryID := range entryIDs {
+		result, err := tx.Exec(stmt, entryID, tagID)
+		if err != nil {
+			return 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		changed += int(n)
+	}
+
+	return changed, tx.Commit()
+}
+
 // GetOrCreateTag retrieves a tag by its name or creates a new one if it does not exist.
 //
 // If a tag with the specified name exists in the database, it returns the corresponding [model.Tag] along with a nil error.
 //
 // If no matching tag is found, a new [model.Tag] is inserted into the database with a unique ID and the current timestamp.
-// The function then returns the newly created tag.
+// The function then returns the newly created tag. Like [GetOrCreateProject], it returns the existing tag if a
+// concurrent caller created it first.
 //
 // In case of a database query or insertion failure, it returns a nil tag and the associated error.
 //
@@ -81,8 +379,11 @@ func GetProjectByID(id string) (*model.Project, error) {
 //   - An error, if any issue occurs during the retrieval or creation process.
 func GetOrCreateTag(name string) (*model.Tag, error) {
 	var t model.Tag
-	err := DB.QueryRow("SELECT id, name, created_at FROM tags WHERE name = ?", name).
-		Scan(&t.ID, &t.Name, &t.CreatedAt)
+	selectTag := func() error {
+		return DB.QueryRow("SELECT id, name, created_at FROM tags WHERE name = ?", name).
+			Scan(&t.ID, &t.Name, localTime{&t.CreatedAt})
+	}
+	err := selectTag()
 	if err == nil {
 		return &t, nil
 	}
@@ -90,18 +391,15 @@ func GetOrCreateTag(name string) (*model.Tag, error) {
 		return nil, err
 	}
 
-	id := model.NewULID()
-	now := time.Now()
-	_, err = DB.Exec("INSERT INTO tags (id, name, created_at) VALUES (?, ?, ?)", id, name, now)
+	_, err = DB.Exec("INSERT INTO tags (id, name, created_at) VALUES (?, ?, ?) ON CONFLICT(name) DO NOTHING",
+		model.NewULID(), name, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
-
-	return &model.Tag{
-		ID:        id,
-		Name:      name,
-		CreatedAt: now,
-	}, nil
+	if err := selectTag(); err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 // GetTagsForEntry retrieves all [model.Tag]s associated with a given entry specified by entryID.
@@ -126,7 +424,7 @@ func GetTagsForEntry(entryID string) ([]model.Tag, error) {
 	var tags []model.Tag
 	for rows.Next() {
 		var t model.Tag
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, localTime{&t.CreatedAt}); err != nil {
 			return nil, err
 		}
 		tags = append(tags, t)
@@ -155,6 +453,13 @@ func GetTagsForEntry(entryID string) ([]model.Tag, error) {
 //   - An error if any issues occur during the database operation, such as issues with the transaction or database
 //     constraints.
 func CreateEntry(projectID string, title string, tagIDs []string) (*model.Entry, error) {
+	return CreateTimerEntry(projectID, title, tagIDs, "")
+}
+
+// CreateTimerEntry creates a running entry like [CreateEntry] on the named timer. An empty timer is the default one.
+//
+// Callers are responsible for checking that the timer is not already active with [GetRunningEntryForTimer].
+func CreateTimerEntry(projectID string, title string, tagIDs []string, timer string) (*model.Entry, error) {
 	tx, err := DB.Begin()
 	if err != nil {
 		return nil, err
@@ -162,10 +467,10 @@ func CreateEntry(projectID string, title string, tagIDs []string) (*model.Entry,
 	defer tx.Rollback()
 
 	entryID := model.NewULID()
-	now := time.Now()
+	now := Now()
 	_, err = tx.Exec(
-		"INSERT INTO entries (id, project_id, title, start_time, status) VALUES (?, ?, ?, ?, ?)",
-		entryID, projectID, title, now, model.StatusRunning)
+		"INSERT INTO entries (id, project_id, title, start_time, status, timer) VALUES (?, ?, ?, ?, ?, ?)",
+		entryID, projectID, title, now.UTC(), model.StatusRunning, timer)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +491,8 @@ func CreateEntry(projectID string, title string, tagIDs []string) (*model.Entry,
 		ID:        entryID,
 		ProjectID: projectID,
 		Title:     title,
+		Timer:     timer,
+		Billable:  true,
 		StartTime: now,
 		Status:    model.StatusRunning,
 	}, nil
@@ -202,7 +509,7 @@ func CreateEntryAt(projectID string, title string, tagIDs []string, startTime ti
 	entryID := model.NewULID()
 	_, err = tx.Exec(
 		"INSERT INTO entries (id, project_id, title, start_time, status) VALUES (?, ?, ?, ?, ?)",
-		entryID, projectID, title, startTime, model.StatusRunning)
+		entryID, projectID, title, startTime.UTC(), model.StatusRunning)
 	if err != nil {
 		return nil, err
 	}
@@ -221,113 +528,244 @@ func CreateEntryAt(projectID string, title string, tagIDs []string, startTime ti
 	return GetEntryByID(entryID)
 }
 
-// GetLastEntryForProject retrieves the most recent entry for a given project ID.
-func GetLastEntryForProject(projectID string) (*model.Entry, error) {
-	var id string
-	err := DB.QueryRow("SELECT id FROM entries WHERE project_id = ? ORDER BY start_time DESC LIMIT 1", projectID).Scan(&id)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-	return GetEntryByID(id)
-}
-
-// GetRunningEntry retrieves the most recent time entry with a status of 'running' or 'paused'.
+// CreateCompletedEntry creates a stopped entry spanning startTime to endTime, associating it with a project and
+// optional tags.
 //
-// It queries the database for the active entry, ordering by start time in descending order, and applies limitations to return
-// only one result. If no such entry exists, it returns nil without error. If any database interaction fails, it returns an error.
+// This is used to log work after the fact. The caller is responsible for validating that endTime is after startTime
+// and for checking overlaps with [EntriesOverlapping].
 //
-// The function augments the retrieved entry with associated data, including its project, tags, and pauses:
+// Returns the fully populated [model.Entry], or an error if the transaction fails.
+func CreateCompletedEntry(projectID string, title string, tagIDs []string, startTime, endTime time.Time) (*model.Entry, error) {
+	return CreateImportedEntry(projectID, title, tagIDs, startTime, endTime, "")
+}
+
+// CreateImportedEntry creates a stopped entry like [CreateCompletedEntry], recording sourceID as the identifier the
+// entry had in the tool it was imported from. An empty sourceID is stored as NULL.
 //
-//   - Project information is loaded via [GetProjectByID].
-//   - Tag associations are retrieved using [GetTagsForEntry].
-//   - Pause details are loaded using [GetPausesForEntry].
+// Use [HasEntryWithSourceID] to skip records that were already imported.
 //
-// Returns:
-//   - A pointer to a complete [model.Entry] containing associated details if an active entry is found.
-//   - Nil if no entry is running or paused.
-//   - An error if any database queries or related function calls fail.
-func GetRunningEntry() (*model.Entry, error) {
-	var e model.Entry
-	var endTime sql.NullTime
-	err := DB.QueryRow(`
-		SELECT id, project_id, title, start_time, end_time, status
-		FROM entries
-		WHERE status IN ('running', 'paused')
-		ORDER BY start_time DESC LIMIT 1`).
-		Scan(&e.ID, &e.ProjectID, &e.Title, &e.StartTime, &endTime, &e.Status)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+// Returns the fully populated [model.Entry], or an error if the transaction fails.
+func CreateImportedEntry(projectID string, title string, tagIDs []string, startTime, endTime time.Time, sourceID string) (*model.Entry, error) {
+	tx, err := DB.Begin()
 	if err != nil {
 		return nil, err
 	}
-	if endTime.Valid {
-		e.EndTime = &endTime.Time
-	}
+	defer tx.Rollback()
 
-	// Load project
-	project, err := GetProjectByID(e.ProjectID)
+	entryID := model.NewULID()
+	_, err = tx.Exec(
+		"INSERT INTO entries (id, project_id, title, start_time, end_time, status, source_id) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entryID, projectID, title, startTime.UTC(), endTime.UTC(), model.StatusStopped,
+		sql.NullString{String: sourceID, Valid: sourceID != ""})
 	if err != nil {
 		return nil, err
 	}
-	e.Project = project
 
-	// Load tags
-	tags, err := GetTagsForEntry(e.ID)
-	if err != nil {
-		return nil, err
+	for _, tagID := range tagIDs {
+		_, err = tx.Exec("INSERT INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", entryID, tagID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	e.Tags = tags
 
-	// Load pauses
-	pauses, err := GetPausesForEntry(e.ID)
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
-	e.Pauses = pauses
 
-	return &e, nil
+	return GetEntryByID(entryID)
 }
 
-// GetEntryByID retrieves a time entry from the database based on its ID.
-//
-// The function queries the `entries` table to fetch the relevant entry's details, including project information,
-// associated tags, and any pauses linked to the entry.
+// HasEntryWithSourceID reports whether an entry was already imported with the given source ID.
+func HasEntryWithSourceID(sourceID string) (bool, error) {
+	var exists bool
+	err := DB.QueryRow("SELECT EXISTS (SELECT 1 FROM entries WHERE source_id = ?)", sourceID).Scan(&exists)
+	return exists, err
+}
+
+// HasEntry reports whether an entry with the given ID exists.
+func HasEntry(id string) (bool, error) {
+	var exists bool
+	err := DB.QueryRow("SELECT EXISTS (SELECT 1 FROM entries WHERE id = ?)", id).Scan(&exists)
+	return exists, err
+}
+
+// RestoreEntry inserts e as it was exported, keeping its ID, source ID, status, times, note, timer, billing fields,
+// estimate, planned end, tags, and pauses, in a single transaction.
 //
-// - id: The unique identifier of the entry to retrieve.
+// The caller resolves e.ProjectID and the IDs in e.Tags to rows of this database, and checks with [HasEntry] that the
+// entry does not exist yet. Pauses without an ID are given a new one.
 //
-// Returns a pointer to [model.Entry], fully populated with its project, tags, and pauses.
-// Returns an error if the entry is not found or if any database operation fails, such as retrieving related data.
-func GetEntryByID(id string) (*model.Entry, error) {
-	var e model.Entry
-	var endTime sql.NullTime
-	err := DB.QueryRow(`
-		SELECT id, project_id, title, start_time, end_time, status
-		FROM entries WHERE id = ?`, id).
-		Scan(&e.ID, &e.ProjectID, &e.Title, &e.StartTime, &endTime, &e.Status)
+// Returns an error if any statement or the commit fails.
+func RestoreEntry(e *model.Entry) error {
+	tx, err := DB.Begin()
 	if err != nil {
-		return nil, err
-	}
-	if endTime.Valid {
-		e.EndTime = &endTime.Time
+		return err
 	}
+	defer tx.Rollback()
 
-	project, err := GetProjectByID(e.ProjectID)
+	var estimate *int64
+	if e.Estimate != nil {
+		s := int64(e.Estimate.Seconds())
+		estimate = &s
+	}
+	_, err = tx.Exec(`
+		INSERT INTO entries (id, project_id, title, note, timer, source_id, billable, start_time, end_time, status, billed_at, estimate, planned_end)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID, e.ProjectID, e.Title,
+		sql.NullString{String: e.Note, Valid: e.Note != ""}, sql.NullString{String: e.Timer, Valid: e.Timer != ""},
+		sql.NullString{String: e.SourceID, Valid: e.SourceID != ""}, e.Billable, e.StartTime.UTC(), utcPtr(e.EndTime), e.Status, utcPtr(e.BilledAt), estimate, utcPtr(e.PlannedEnd))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	e.Project = project
 
-	tags, err := GetTagsForEntry(e.ID)
-	if err != nil {
-		return nil, err
+	for _, t := range e.Tags {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", e.ID, t.ID); err != nil {
+			return err
+		}
 	}
-	e.Tags = tags
 
-	pauses, err := GetPausesForEntry(e.ID)
+	for _, p := range e.Pauses {
+		id := p.ID
+		if id == "" {
+			id = model.NewULID()
+		}
+		_, err := tx.Exec("INSERT INTO pauses (id, entry_id, pause_time, resume_time, reason) VALUES (?, ?, ?, ?, ?)",
+			id, e.ID, p.PauseTime.UTC(), utcPtr(p.ResumeTime), p.Reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SwitchEntry stops the entry identified by stopID and creates a new running entry in a single transaction.
+//
+// Any open pauses on the stopped entry are closed at the same instant the new entry starts, so no time is lost
+// or double-counted between the two entries. The new entry runs on the same timer as the stopped one.
+//
+//   - stopID: The identifier of the running or paused entry to stop.
+//   - projectID, title, tagIDs: The details of the new entry, as for [CreateEntry].
+//
+// Returns the newly created [model.Entry], or an error if any statement or the commit fails.
+func SwitchEntry(stopID string, projectID string, title string, tagIDs []string) (*model.Entry, error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := Now()
+
+	_, err = tx.Exec("UPDATE pauses SET resume_time = ? WHERE entry_id = ? AND resume_time IS NULL", now.UTC(), stopID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE entries SET end_time = ?, status = ? WHERE id = ?", now.UTC(), model.StatusStopped, stopID)
+	if err != nil {
+		return nil, err
+	}
+
+	var timer string
+	err = tx.QueryRow("SELECT COALESCE(timer, '') FROM entries WHERE id = ?", stopID).Scan(&timer)
+	if err != nil {
+		return nil, err
+	}
+
+	entryID := model.NewULID()
+	_, err = tx.Exec(
+		"INSERT INTO entries (id, project_id, title, start_time, status, timer) VALUES (?, ?, ?, ?, ?, ?)",
+		entryID, projectID, title, now.UTC(), model.StatusRunning, timer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tagID := range tagIDs {
+		_, err = tx.Exec("INSERT INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", entryID, tagID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &model.Entry{
+		ID:        entryID,
+		ProjectID: projectID,
+		Title:     title,
+		Timer:     timer,
+		Billable:  true,
+		StartTime: now,
+		Status:    model.StatusRunning,
+	}, nil
+}
+
+// GetLastEntryForProject retrieves the most recent entry for a given project ID.
+func GetLastEntryForProject(projectID string) (*model.Entry, error) {
+	var id string
+	err := DB.QueryRow("SELECT id FROM entries WHERE project_id = ? ORDER BY start_time DESC LIMIT 1", projectID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return GetEntryByID(id)
+}
+
+// GetRunningEntry retrieves the most recent time entry with a status of 'running' or 'paused'.
+//
+// It queries the database for the active entry, ordering by start time in descending order, and applies limitations to return
+// only one result. If no such entry exists, it returns nil without error. If any database interaction fails, it returns an error.
+//
+// The function augments the retrieved entry with associated data, including its project, tags, and pauses:
+//
+//   - Project information is loaded via [GetProjectByID].
+//   - Tag associations are retrieved using [GetTagsForEntry].
+//   - Pause details are loaded using [GetPausesForEntry].
+//
+// Returns:
+//   - A pointer to a complete [model.Entry] containing associated details if an active entry is found.
+//   - Nil if no entry is running or paused.
+//   - An error if any database queries or related function calls fail.
+func GetRunningEntry() (*model.Entry, error) {
+	var e model.Entry
+	var endTime sql.NullTime
+	err := DB.QueryRow(`
+		SELECT id, project_id, title, COALESCE(note, ''), COALESCE(timer, ''), COALESCE(source_id, ''), billable, start_time, end_time, status, billed_at, estimate, planned_end
+		FROM entries
+		WHERE status IN ('running', 'paused')
+		ORDER BY start_time DESC LIMIT 1`).
+		Scan(&e.ID, &e.ProjectID, &e.Title, &e.Note, &e.Timer, &e.SourceID, &e.Billable, localTime{&e.StartTime}, &endTime, &e.Status, nullLocalTime{&e.BilledAt}, nullDuration{&e.Estimate}, nullLocalTime{&e.PlannedEnd})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if endTime.Valid {
+		e.EndTime = localPtr(endTime)
+	}
+
+	// Load project
+	project, err := GetProjectByID(e.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	e.Project = project
+
+	// Load tags
+	tags, err := GetTagsForEntry(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	e.Tags = tags
+
+	// Load pauses
+	pauses, err := GetPausesForEntry(e.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +774,222 @@ func GetEntryByID(id string) (*model.Entry, error) {
 	return &e, nil
 }
 
+// GetRunningEntryForTimer retrieves the most recent running or paused entry on the named timer, fully populated with
+// its project, tags, and pauses. An empty timer is the default one used when no --timer is given.
+//
+// Returns nil without error if the timer is not active, or an error if any query fails.
+func GetRunningEntryForTimer(timer string) (*model.Entry, error) {
+	entries, err := loadEntries(`
+		SELECT `+entryColumns+` FROM entries e
+		WHERE e.status IN ('running', 'paused') AND COALESCE(e.timer, '') = ?
+		ORDER BY e.start_time DESC LIMIT 1`, timer)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// CountActiveEntries returns the number of entries that are currently running or paused on each timer, keyed by timer
+// name. The default timer is keyed by the empty string, and timers with no active entry are left out.
+//
+// Normally at most one entry is active per timer, but crashes or syncing a database between machines can leave several.
+//
+// Returns the counts, or an error if the query fails.
+func CountActiveEntries() (map[string]int, error) {
+	rows, err := DB.Query(`
+		SELECT COALESCE(timer, ''), COUNT(*) FROM entries
+		WHERE status IN ('running', 'paused')
+		GROUP BY COALESCE(timer, '')`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var timer string
+		var count int
+		if err := rows.Scan(&timer, &count); err != nil {
+			return nil, err
+		}
+		counts[timer] = count
+	}
+	return counts, rows.Err()
+}
+
+// ListActiveEntries retrieves all running or paused entries, most recent first, fully populated with their project,
+// tags, and pauses.
+//
+// Returns the entries, or an error if any query fails.
+func ListActiveEntries() ([]model.Entry, error) {
+	return loadEntries(`
+		SELECT ` + entryColumns + ` FROM entries e
+		WHERE e.status IN ('running', 'paused')
+		ORDER BY e.start_time DESC`)
+}
+
+// ListOverdueEntries retrieves the running or paused entries whose planned end (see [SetEntryPlannedEnd]) is at or
+// before now, oldest first, fully populated with their project, tags, and pauses.
+//
+// Returns the entries, or an error if any query fails.
+func ListOverdueEntries(now time.Time) ([]model.Entry, error) {
+	return loadEntries(`
+		SELECT `+entryColumns+` FROM entries e
+		WHERE e.status IN ('running', 'paused') AND e.planned_end IS NOT NULL AND e.planned_end <= ?
+		ORDER BY e.start_time`, now.UTC())
+}
+
+// EntriesOverlapping retrieves all entries whose time range overlaps [start, end).
+//
+// Running and paused entries are treated as extending to the present. Entries that merely touch the range (ending
+// exactly at start or starting exactly at end) do not overlap it.
+//
+// Returns the overlapping entries ordered by start time, or an error if any query fails.
+func EntriesOverlapping(start, end time.Time) ([]model.Entry, error) {
+	return loadEntries(`
+		SELECT `+entryColumns+` FROM entries e
+		WHERE e.start_time < ? AND COALESCE(e.end_time, ?) > ?
+		ORDER BY e.start_time`, end.UTC(), time.Now().UTC(), start.UTC())
+}
+
+// entryColumns lists the columns of an entry, from the `entries` table aliased as `e`, in the order [scanEntry] reads
+// them.
+const entryColumns = `e.id, e.project_id, e.title, COALESCE(e.note, ''), COALESCE(e.timer, ''), COALESCE(e.source_id, ''),
+	e.billable, e.start_time, e.end_time, e.status, e.billed_at, e.estimate, e.planned_end`
+
+// scanEntry reads a row selecting [entryColumns] into a [model.Entry], without its project, tags, or pauses.
+func scanEntry(rows *sql.Rows) (model.Entry, error) {
+	var e model.Entry
+	var endTime sql.NullTime
+	if err := rows.Scan(&e.ID, &e.ProjectID, &e.Title, &e.Note, &e.Timer, &e.SourceID, &e.Billable, localTime{&e.StartTime}, &endTime, &e.Status, nullLocalTime{&e.BilledAt}, nullDuration{&e.Estimate}, nullLocalTime{&e.PlannedEnd}); err != nil {
+		return e, err
+	}
+	if endTime.Valid {
+		e.EndTime = localPtr(endTime)
+	}
+	return e, nil
+}
+
+// loadEntries runs query, which must select [entryColumns], and populates the matching entries with their projects,
+// tags, and pauses using [attachRelations].
+//
+// The entry rows are fully read before the relations are loaded, so only one query is open at a time, and the number
+// of queries does not grow with the number of entries.
+func loadEntries(query string, args ...interface{}) ([]model.Entry, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []model.Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := attachRelations(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEntryByID retrieves a time entry from the database based on its ID.
+//
+// The function queries the `entries` table to fetch the relevant entry's details, including project information,
+// associated tags, and any pauses linked to the entry.
+//
+// - id: The unique identifier of the entry to retrieve.
+//
+// Returns a pointer to [model.Entry], fully populated with its project, tags, and pauses.
+// Returns an error if the entry is not found or if any database operation fails, such as retrieving related data.
+func GetEntryByID(id string) (*model.Entry, error) {
+	var e model.Entry
+	var endTime sql.NullTime
+	err := DB.QueryRow(`
+		SELECT id, project_id, title, COALESCE(note, ''), COALESCE(timer, ''), COALESCE(source_id, ''), billable, start_time, end_time, status, billed_at, estimate, planned_end
+		FROM entries WHERE id = ?`, id).
+		Scan(&e.ID, &e.ProjectID, &e.Title, &e.Note, &e.Timer, &e.SourceID, &e.Billable, localTime{&e.StartTime}, &endTime, &e.Status, nullLocalTime{&e.BilledAt}, nullDuration{&e.Estimate}, nullLocalTime{&e.PlannedEnd})
+	if err != nil {
+		return nil, err
+	}
+	if endTime.Valid {
+		e.EndTime = localPtr(endTime)
+	}
+
+	project, err := GetProjectByID(e.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	e.Project = project
+
+	tags, err := GetTagsForEntry(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	e.Tags = tags
+
+	pauses, err := GetPausesForEntry(e.ID)
+	if err != nil {
+		return nil, err
+	}
+	e.Pauses = pauses
+
+	return &e, nil
+}
+
+// ResolveEntryID resolves a full or partial entry ID to the full ID of a single entry.
+//
+// An exact match is returned as-is. Otherwise partial is treated as a case-insensitive prefix or suffix, and the ID of
+// the only entry starting or ending with it is returned. Suffixes match the short IDs shown in tables, which are the
+// last characters of the ID.
+//
+// Returns an error if no entry matches, if partial matches more than one entry, or if the query fails.
+func ResolveEntryID(partial string) (string, error) {
+	partial = strings.ToUpper(strings.TrimSpace(partial))
+	if partial == "" {
+		return "", fmt.Errorf("entry ID is required")
+	}
+
+	rows, err := DB.Query("SELECT id FROM entries WHERE substr(id, 1, ?) = ? OR substr(id, -?) = ? ORDER BY id LIMIT 2",
+		len(partial), partial, len(partial), partial)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		if id == partial {
+			return id, nil
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no entry matches ID %s", partial)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("ID %s is ambiguous (matches %s, ...); use more characters", partial, strings.Join(ids[:2], ", "))
+	}
+}
+
 // GetLastEntry retrieves the most recent [model.Entry] from the database based on the latest start time.
 //
 // If no entries exist in the database, the function returns `nil` without an error.
@@ -356,64 +1010,196 @@ func GetLastEntry() (*model.Entry, error) {
 	return GetEntryByID(id)
 }
 
-// StopEntry stops a running time entry by setting its end time and updating its status to [model.StatusStopped].
+// SplitEntry divides a stopped entry into two at the given time.
 //
-// If there are any active pauses associated with the entry, they are marked as resumed with the current time.
+// The original entry keeps its start and ends at `at`; a new entry with the same project, title, note, tags, billing
+// fields, and source ID starts at `at` and keeps the original end. Pauses move to whichever half contains them, and a pause spanning `at` is cut in
+// two. All changes run in one transaction.
 //
-// id is the unique identifier of the time entry to stop.
+// Returns the new second entry, or an error if the entry is not stopped, `at` is not strictly inside the entry, or a
+// database operation fails.
+func SplitEntry(id string, at time.Time) (*model.Entry, error) {
+	entry, err := GetEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != model.StatusStopped || entry.EndTime == nil {
+		return nil, fmt.Errorf("only stopped entries can be split")
+	}
+	if !at.After(entry.StartTime) || !at.Before(*entry.EndTime) {
+		return nil, fmt.Errorf("split time must be between %s and %s",
+			entry.StartTime.Format("2006-01-02 15:04:05"), entry.EndTime.Format("2006-01-02 15:04:05"))
+	}
+
+	tx, err := DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	newID := model.NewULID()
+	_, err = tx.Exec(`
+		INSERT INTO entries (id, project_id, title, note, billable, billed_at, source_id, start_time, end_time, status)
+		SELECT ?, project_id, title, note, billable, billed_at, source_id, ?, end_time, ? FROM entries WHERE id = ?`,
+		newID, at.UTC(), model.StatusStopped, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("UPDATE entries SET end_time = ? WHERE id = ?", at.UTC(), id); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec("INSERT INTO entry_tags (entry_id, tag_id) SELECT ?, tag_id FROM entry_tags WHERE entry_id = ?", newID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range entry.Pauses {
+		resume := *entry.EndTime
+		if p.ResumeTime != nil {
+			resume = *p.ResumeTime
+		}
+
+		switch {
+		case !p.PauseTime.Before(at):
+			// Entirely in the second half
+			if _, err := tx.Exec("UPDATE pauses SET entry_id = ? WHERE id = ?", newID, p.ID); err != nil {
+				return nil, err
+			}
+		case resume.After(at):
+			// Spans the split: end the first part at the split and continue it in the second half
+			if _, err := tx.Exec("UPDATE pauses SET resume_time = ? WHERE id = ?", at.UTC(), p.ID); err != nil {
+				return nil, err
+			}
+			_, err = tx.Exec("INSERT INTO pauses (id, entry_id, pause_time, resume_time, reason) VALUES (?, ?, ?, ?, ?)",
+				model.NewULID(), newID, at.UTC(), resume.UTC(), p.Reason)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return GetEntryByID(newID)
+}
+
+// MergeGapReason is the reason of the pause [MergeEntries] inserts for the time between the merged entries.
+const MergeGapReason = "Gap"
+
+// MergeEntries combines the entry with ID secondID into the entry with ID id, the inverse of [SplitEntry].
 //
-// Returns an error if the database operation to stop the entry or update pauses fails.
-func StopEntry(id string) error {
-	now := time.Now()
+// The merged entry spans from the earlier start to the later entry's end, and takes the later entry's status and
+// timer, so an entry restarted by mistake can be merged while it is still running. It keeps the project, title, note,
+// and billing fields of id, gains the tags and pauses of secondID, and gets a [MergeGapReason] pause for the time
+// between the two. secondID is then deleted. All changes run in one transaction.
+//
+// Returns the merged entry, or an error if the IDs are the same, the earlier entry is not stopped, the entries
+// overlap, another entry lies between them, or a database operation fails.
+func MergeEntries(id, secondID string) (*model.Entry, error) {
+	if id == secondID {
+		return nil, fmt.Errorf("cannot merge an entry with itself")
+	}
+	entry, err := GetEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	second, err := GetEntryByID(secondID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Close any open pauses first
-	_, err := DB.Exec("UPDATE pauses SET resume_time = ? WHERE entry_id = ? AND resume_time IS NULL", now, id)
+	earlier, later := entry, second
+	if later.StartTime.Before(earlier.StartTime) {
+		earlier, later = later, earlier
+	}
+	if earlier.Status != model.StatusStopped || earlier.EndTime == nil {
+		return nil, fmt.Errorf("the earlier entry must be stopped")
+	}
+	if later.StartTime.Before(*earlier.EndTime) {
+		return nil, fmt.Errorf("entries overlap")
+	}
+	if later.StartTime.After(*earlier.EndTime) {
+		between, err := EntriesOverlapping(*earlier.EndTime, later.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range between {
+			if e.ID != id && e.ID != secondID {
+				return nil, fmt.Errorf("entry %s lies between them", e.ID)
+			}
+		}
+	}
+
+	tx, err := DB.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	_, err = DB.Exec("UPDATE entries SET end_time = ?, status = ? WHERE id = ?", now, model.StatusStopped, id)
-	return err
+	_, err = tx.Exec("UPDATE entries SET start_time = ?, end_time = ?, status = ?, timer = ? WHERE id = ?",
+		earlier.StartTime.UTC(), utcPtr(later.EndTime), later.Status,
+		sql.NullString{String: later.Timer, Valid: later.Timer != ""}, id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec("INSERT OR IGNORE INTO entry_tags (entry_id, tag_id) SELECT ?, tag_id FROM entry_tags WHERE entry_id = ?",
+		id, secondID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("UPDATE pauses SET entry_id = ? WHERE entry_id = ?", id, secondID); err != nil {
+		return nil, err
+	}
+	if later.StartTime.After(*earlier.EndTime) {
+		_, err = tx.Exec("INSERT INTO pauses (id, entry_id, pause_time, resume_time, reason) VALUES (?, ?, ?, ?, ?)",
+			model.NewULID(), id, earlier.EndTime.UTC(), later.StartTime.UTC(), MergeGapReason)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM entries WHERE id = ?", secondID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return GetEntryByID(id)
 }
 
-// DeleteEntry removes an entry with the specified ID from the database.
+// StopEntry stops a running time entry by setting its end time and updating its status to [model.StatusStopped].
 //
-// It performs the following actions within a transaction:
+// If there are any active pauses associated with the entry, they are marked as resumed with the current time.
 //
-//   - Deletes all associated pauses from the "pauses" table for the given entry ID.
-//   - Deletes all associated tags from the "entry_tags" table for the given entry ID.
-//   - Deletes the entry itself from the "entries" table.
+// id is the unique identifier of the time entry to stop.
 //
-// The transaction is committed upon successful deletion of all related data. If any step fails, the transaction is
-// rolled back, and an error is returned.
+// Returns an error if the database operation to stop the entry or update pauses fails.
+func StopEntry(id string) error {
+	return StopEntryAt(id, Now())
+}
+
+// StopEntryAt stops a time entry at the given time, closing any open pauses at that time as well.
 //
-// id: The identifier of the entry to delete.
+// Callers are responsible for checking that at is not before the entry's start or its latest pause.
 //
-// Returns an error if:
-//   - A database transaction cannot be created.
-//   - Any step in deleting related data or the entry itself fails.
-//   - Committing the transaction fails.
-func DeleteEntry(id string) error {
+// Returns an error if the database operation to stop the entry or update pauses fails.
+func StopEntryAt(id string, at time.Time) error {
 	tx, err := DB.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// Delete related pauses
-	_, err = tx.Exec("DELETE FROM pauses WHERE entry_id = ?", id)
-	if err != nil {
-		return err
-	}
-
-	// Delete related tags
-	_, err = tx.Exec("DELETE FROM entry_tags WHERE entry_id = ?", id)
+	// Close any open pauses first
+	_, err = tx.Exec("UPDATE pauses SET resume_time = ? WHERE entry_id = ? AND resume_time IS NULL", at.UTC(), id)
 	if err != nil {
 		return err
 	}
 
-	// Delete entry
-	_, err = tx.Exec("DELETE FROM entries WHERE id = ?", id)
+	_, err = tx.Exec("UPDATE entries SET end_time = ?, status = ? WHERE id = ?", at.UTC(), model.StatusStopped, id)
 	if err != nil {
 		return err
 	}
@@ -421,6 +1207,19 @@ func DeleteEntry(id string) error {
 	return tx.Commit()
 }
 
+// DeleteEntry removes an entry with the specified ID from the database.
+//
+// Its pauses and tag links are deleted with it by the ON DELETE CASCADE foreign keys of the "pauses" and "entry_tags"
+// tables.
+//
+// id: The identifier of the entry to delete.
+//
+// Returns an error if deleting the entry fails.
+func DeleteEntry(id string) error {
+	_, err := DB.Exec("DELETE FROM entries WHERE id = ?", id)
+	return err
+}
+
 // PauseEntry creates a pause entry for the given `id` and updates its status to paused.
 //
 // A new ULID is generated for the pause entry, and the current timestamp is used as the pause time.
@@ -432,9 +1231,19 @@ func DeleteEntry(id string) error {
 // reason:
 //   - The explanation or context for the pause. It is stored in the database for potential auditing or user reference.
 //
-// Returns an error if database operations fail, including transaction initiation, insertion, or update queries.
-// The transaction is rolled back in any error case to ensure consistency.
+// Only one pause can be open at a time, so an entry that already has an open pause (see [HasOpenPause]) is refused.
+//
+// Returns an error if the entry already has an open pause, or if database operations fail, including transaction
+// initiation, insertion, or update queries. The transaction is rolled back in any error case to ensure consistency.
 func PauseEntry(id string, reason string) error {
+	open, err := HasOpenPause(id)
+	if err != nil {
+		return err
+	}
+	if open {
+		return fmt.Errorf("entry already has an open pause")
+	}
+
 	tx, err := DB.Begin()
 	if err != nil {
 		return err
@@ -442,9 +1251,9 @@ func PauseEntry(id string, reason string) error {
 	defer tx.Rollback()
 
 	pauseID := model.NewULID()
-	now := time.Now()
+	now := Now()
 
-	_, err = tx.Exec("INSERT INTO pauses (id, entry_id, pause_time, reason) VALUES (?, ?, ?, ?)", pauseID, id, now, reason)
+	_, err = tx.Exec("INSERT INTO pauses (id, entry_id, pause_time, reason) VALUES (?, ?, ?, ?)", pauseID, id, now.UTC(), reason)
 	if err != nil {
 		return err
 	}
@@ -477,9 +1286,9 @@ func ResumeEntry(id string) error {
 	}
 	defer tx.Rollback()
 
-	now := time.Now()
+	now := Now()
 
-	_, err = tx.Exec("UPDATE pauses SET resume_time = ? WHERE entry_id = ? AND resume_time IS NULL", now, id)
+	_, err = tx.Exec("UPDATE pauses SET resume_time = ? WHERE entry_id = ? AND resume_time IS NULL", now.UTC(), id)
 	if err != nil {
 		return err
 	}
@@ -497,6 +1306,68 @@ func ReopenEntry(id string) error {
 	return err
 }
 
+// SetEntryNote replaces the note of the entry identified by id. An empty note clears it.
+//
+// Returns an error if the entry does not exist or the update fails.
+func SetEntryNote(id, note string) error {
+	result, err := DB.Exec("UPDATE entries SET note = ? WHERE id = ?", note, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("entry %s not found", id)
+	}
+	return nil
+}
+
+// SetEntryBillable marks the entry with the given ID as billable or not.
+func SetEntryBillable(id string, billable bool) error {
+	_, err := DB.Exec("UPDATE entries SET billable = ? WHERE id = ?", billable, id)
+	return err
+}
+
+// SetEntryEstimate sets the estimated duration of the entry with the given ID, stored in whole seconds. A nil estimate
+// removes it.
+func SetEntryEstimate(id string, estimate *time.Duration) error {
+	var seconds *int64
+	if estimate != nil {
+		s := int64(estimate.Seconds())
+		seconds = &s
+	}
+	_, err := DB.Exec("UPDATE entries SET estimate = ? WHERE id = ?", seconds, id)
+	return err
+}
+
+// SetEntryPlannedEnd sets the time the entry with the given ID is planned to stop at, for [ListOverdueEntries]. A nil
+// time removes it.
+func SetEntryPlannedEnd(id string, plannedEnd *time.Time) error {
+	_, err := DB.Exec("UPDATE entries SET planned_end = ? WHERE id = ?", utcPtr(plannedEnd), id)
+	return err
+}
+
+// MarkBilled stamps the entries with the given IDs as billed at the given time, in a single transaction. Billed
+// entries are left out of reports by default, so invoiced time is not charged twice.
+//
+// Returns an error if any update fails, in which case no entry is marked.
+func MarkBilled(entryIDs []string, at time.Time) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range entryIDs {
+		if _, err := tx.Exec("UPDATE entries SET billed_at = ? WHERE id = ?", at.UTC(), id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // UpdateEntry updates an entry in the database with the given parameters.
 //
 // The function modifies the entry identified by id by updating its projectID, title, and optional startTime and endTime.
@@ -509,159 +1380,433 @@ func ReopenEntry(id string) error {
 //   - id: The unique identifier of the entry to update.
 //   - projectID: The identifier of the project associated with the entry.
 //   - title: The new title of the entry.
+//   - note: The new note of the entry, or empty for none.
+//   - billable: Whether the entry is billable.
 //   - startTime, endTime: Optional timestamps for the entry's start and end times. Provide as pointers, or nil to skip updates.
 //   - tagIDs: A slice of strings representing the tags to associate with the entry.
 //
-// Returns an error if the database operation fails, including transaction commit errors.
-func UpdateEntry(id string, projectID string, title string, startTime, endTime *time.Time, tagIDs []string) error {
-	tx, err := DB.Begin()
-	if err != nil {
-		return err
+// Returns an error if the database operation fails, including transaction commit errors.
+func UpdateEntry(id string, projectID string, title string, note string, billable bool, startTime, endTime *time.Time, tagIDs []string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if startTime != nil && endTime != nil {
+		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ?, note = ?, billable = ?, start_time = ?, end_time = ? WHERE id = ?",
+			projectID, title, note, billable, startTime.UTC(), endTime.UTC(), id)
+	} else if startTime != nil {
+		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ?, note = ?, billable = ?, start_time = ? WHERE id = ?",
+			projectID, title, note, billable, startTime.UTC(), id)
+	} else {
+		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ?, note = ?, billable = ? WHERE id = ?",
+			projectID, title, note, billable, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Update tags - remove old and add new
+	_, err = tx.Exec("DELETE FROM entry_tags WHERE entry_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	for _, tagID := range tagIDs {
+		_, err = tx.Exec("INSERT INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", id, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListEntriesOptions represents the parameters available for filtering and retrieving time entries.
+//
+// It includes options to limit the number of results, filter entries by project, associate specified tags,
+// and constrain the time range by specifying start and end dates. Providing a ProjectID or a list of TagIDs
+// narrows the results to entries associated with certain projects or tags. The From and To fields allow for
+// date-based filtering of entries.
+//
+// The Limit field controls the maximum number of entries to be retrieved. If set to 0, all matching entries
+// are retrieved.
+//
+//   - Limit defines the maximum count of entries to return.
+//   - Offset skips that many matching entries first, for loading them a page at a time (see [EachEntry]).
+//   - ProjectID specifies an optional project scope to filter entries.
+//   - TagIDs is a list of tag identifiers used to refine the search. Entries must have all of them, or any one of them
+//     when AnyTag is set.
+//   - From restricts the entries to those starting on or after the specified time.
+//   - To restricts the entries to those starting before the specified time.
+//   - TitleSearch restricts the entries to those whose title contains the given text, ignoring case.
+//   - Status restricts the entries to those with the given status.
+//   - ExcludeArchived leaves out entries of archived projects.
+//   - Billable restricts the entries to billable or non-billable ones.
+//   - Billed restricts the entries to those already marked billed with [MarkBilled], or to those not yet billed.
+//   - SortBy orders the entries by one of [EntrySortFields], or by start time if empty. Ties are broken by start time,
+//     then by ID.
+//   - Ascending returns the entries in ascending order instead of the default descending order.
+type ListEntriesOptions struct {
+	Limit           int
+	Offset          int
+	ProjectID       *string
+	TagIDs          []string
+	AnyTag          bool
+	From            *time.Time
+	To              *time.Time
+	TitleSearch     *string
+	Status          *model.EntryStatus
+	ExcludeArchived bool
+	Billable        *bool
+	Billed          *bool
+	SortBy          string
+	Ascending       bool
+}
+
+// EntrySortFields lists the accepted values of [ListEntriesOptions.SortBy].
+var EntrySortFields = []string{"start", "end", "duration", "project", "title"}
+
+// entrySortColumns maps each of [EntrySortFields] to the SQL expression it orders by. Only these expressions are ever
+// placed in the ORDER BY clause, so SortBy cannot inject SQL.
+//
+// The duration expression measures open entries and open pauses up to its two "now" parameters, matching
+// [model.Entry.Duration].
+var entrySortColumns = map[string]string{
+	"start": "e.start_time",
+	"end":   "COALESCE(e.end_time, ?)",
+	"duration": `(julianday(COALESCE(e.end_time, ?)) - julianday(e.start_time)
+		- COALESCE((SELECT SUM(julianday(COALESCE(p.resume_time, ?)) - julianday(p.pause_time))
+			FROM pauses p WHERE p.entry_id = e.id), 0))`,
+	"project": "(SELECT name FROM projects WHERE id = e.project_id) COLLATE NOCASE",
+	"title":   "e.title COLLATE NOCASE",
+}
+
+// entryOrder builds the ORDER BY clause and its arguments for the SortBy and Ascending options of opts.
+//
+// Returns an error if SortBy is not one of [EntrySortFields].
+func entryOrder(opts ListEntriesOptions) (string, []interface{}, error) {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = "start"
+	}
+	column, ok := entrySortColumns[sortBy]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid sort field: %s", opts.SortBy)
+	}
+
+	direction := "DESC"
+	if opts.Ascending {
+		direction = "ASC"
+	}
+
+	var args []interface{}
+	now := time.Now().UTC()
+	for range strings.Count(column, "?") {
+		args = append(args, now)
+	}
+
+	// The ID breaks remaining ties, so pages of the same query never overlap
+	order := " ORDER BY " + column + " " + direction
+	if sortBy != "start" {
+		order += ", e.start_time " + direction
+	}
+	order += ", e.id " + direction
+	return order, args, nil
+}
+
+// ListEntries retrieves a list of time tracking entries based on the provided [ListEntriesOptions] filters.
+//
+// The function constructs a SQL query dynamically based on the filters in opts. These filters include project ID,
+// tag IDs, time range (From and To), and the maximum number of records to retrieve (Limit). It ensures only the
+// desired dataset is returned.
+//
+// The entries are ordered by start time, latest first, unless opts selects another order (see [entryOrder]).
+//
+// The function populates additional details for each entry, such as the associated project, tags, and pauses.
+// Errors encountered in database interactions or data mapping are returned.
+//
+//   - opts: Filters for retrieving entries, including time range, project, tags, and result limit.
+//
+// Returns:
+//   - A slice of [model.Entry] containing the relevant entries, or an error if something goes wrong.
+func ListEntries(opts ListEntriesOptions) ([]model.Entry, error) {
+	where, args := entryFilter(opts)
+	query := `
+		SELECT DISTINCT ` + entryColumns + `
+		FROM entries e
+		LEFT JOIN entry_tags et ON e.id = et.entry_id
+		WHERE ` + where
+
+	order, orderArgs, err := entryOrder(opts)
+	if err != nil {
+		return nil, err
+	}
+	query += order
+	args = append(args, orderArgs...)
+
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	} else if opts.Offset > 0 {
+		// SQLite only accepts OFFSET after a LIMIT; a negative one means no limit
+		query += " LIMIT -1"
+	}
+	if opts.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, opts.Offset)
+	}
+
+	return loadEntries(query, args...)
+}
+
+// entryPageSize is the number of entries [EachEntry] loads at a time.
+var entryPageSize = 500
+
+// EachEntry calls fn for each entry matching opts, in the order [ListEntries] returns them, fully populated with its
+// project, tags, and pauses.
+//
+// Entries are loaded [entryPageSize] at a time, so memory use does not grow with the number of entries. opts.Limit and
+// opts.Offset are respected.
+//
+// Returns the first error from a query or from fn, which stops the iteration.
+func EachEntry(opts ListEntriesOptions, fn func(model.Entry) error) error {
+	remaining := opts.Limit
+	page := opts
+	for {
+		page.Limit = entryPageSize
+		if remaining > 0 {
+			page.Limit = min(page.Limit, remaining)
+		}
+
+		entries, err := ListEntries(page)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+
+		if len(entries) < page.Limit {
+			return nil
+		}
+		if remaining > 0 {
+			if remaining -= len(entries); remaining == 0 {
+				return nil
+			}
+		}
+		page.Offset += len(entries)
+	}
+}
+
+// maxBatchParams caps the number of IDs bound into a single `IN (...)` clause, well below SQLite's variable limit.
+const maxBatchParams = 500
+
+// attachRelations loads the projects, tags, and pauses of entries with one query per relation and stitches them into
+// the entries in place, instead of querying per entry.
+//
+// Tags and pauses are left nil for entries that have none, and pauses are ordered by pause time, matching
+// [GetEntryByID].
+//
+// Returns an error if any of the queries fail.
+func attachRelations(entries []model.Entry) error {
+	if len(entries) == 0 {
+		return nil
 	}
-	defer tx.Rollback()
 
-	if startTime != nil && endTime != nil {
-		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ?, start_time = ?, end_time = ? WHERE id = ?",
-			projectID, title, *startTime, *endTime, id)
-	} else if startTime != nil {
-		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ?, start_time = ? WHERE id = ?",
-			projectID, title, *startTime, id)
-	} else {
-		_, err = tx.Exec("UPDATE entries SET project_id = ?, title = ? WHERE id = ?",
-			projectID, title, id)
+	entryIDs := make([]string, len(entries))
+	seenProjects := make(map[string]bool)
+	var projectIDs []string
+	for i, e := range entries {
+		entryIDs[i] = e.ID
+		if !seenProjects[e.ProjectID] {
+			seenProjects[e.ProjectID] = true
+			projectIDs = append(projectIDs, e.ProjectID)
+		}
 	}
+
+	projects := make(map[string]*model.Project)
+	err := queryInBatches("SELECT id, name, archived, created_at FROM projects WHERE id IN (%s)", projectIDs, func(rows *sql.Rows) error {
+		var p model.Project
+		if err := rows.Scan(&p.ID, &p.Name, &p.Archived, localTime{&p.CreatedAt}); err != nil {
+			return err
+		}
+		projects[p.ID] = &p
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	// Update tags - remove old and add new
-	_, err = tx.Exec("DELETE FROM entry_tags WHERE entry_id = ?", id)
+	tags := make(map[string][]model.Tag)
+	err = queryInBatches(`
+		SELECT et.entry_id, t.id, t.name, t.created_at
+		FROM tags t
+		JOIN entry_tags et ON t.id = et.tag_id
+		WHERE et.entry_id IN (%s)`, entryIDs, func(rows *sql.Rows) error {
+		var entryID string
+		var t model.Tag
+		if err := rows.Scan(&entryID, &t.ID, &t.Name, localTime{&t.CreatedAt}); err != nil {
+			return err
+		}
+		tags[entryID] = append(tags[entryID], t)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	for _, tagID := range tagIDs {
-		_, err = tx.Exec("INSERT INTO entry_tags (entry_id, tag_id) VALUES (?, ?)", id, tagID)
-		if err != nil {
+	pauses := make(map[string][]model.Pause)
+	err = queryInBatches(`
+		SELECT id, entry_id, pause_time, resume_time, COALESCE(reason, 'Manual')
+		FROM pauses
+		WHERE entry_id IN (%s)
+		ORDER BY pause_time`, entryIDs, func(rows *sql.Rows) error {
+		var p model.Pause
+		var resumeTime sql.NullTime
+		if err := rows.Scan(&p.ID, &p.EntryID, localTime{&p.PauseTime}, &resumeTime, &p.Reason); err != nil {
 			return err
 		}
+		if resumeTime.Valid {
+			p.ResumeTime = localPtr(resumeTime)
+		}
+		pauses[p.EntryID] = append(pauses[p.EntryID], p)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return tx.Commit()
+	for i := range entries {
+		entries[i].Project = projects[entries[i].ProjectID]
+		entries[i].Tags = tags[entries[i].ID]
+		entries[i].Pauses = pauses[entries[i].ID]
+	}
+	return nil
 }
 
-// ListEntriesOptions represents the parameters available for filtering and retrieving time entries.
-//
-// It includes options to limit the number of results, filter entries by project, associate specified tags,
-// and constrain the time range by specifying start and end dates. Providing a ProjectID or a list of TagIDs
-// narrows the results to entries associated with certain projects or tags. The From and To fields allow for
-// date-based filtering of entries.
-//
-// The Limit field controls the maximum number of entries to be retrieved. If set to 0, all matching entries
-// are retrieved.
+// queryInBatches runs query once per batch of at most [maxBatchParams] ids, substituting the batch's placeholders
+// for the `%s` in query, and calls scan for every returned row.
 //
-// - Limit defines the maximum count of entries to return.
-// - ProjectID specifies an optional project scope to filter entries.
-// - TagIDs is a list of tag identifiers used to refine the search.
-// - From restricts the entries to those starting on or after the specified time.
-// - To restricts the entries to those starting before the specified time.
-type ListEntriesOptions struct {
-	Limit     int
-	ProjectID *string
-	TagIDs    []string
-	From      *time.Time
-	To        *time.Time
+// Returns the first error from a query or from scan.
+func queryInBatches(query string, ids []string, scan func(*sql.Rows) error) error {
+	for start := 0; start < len(ids); start += maxBatchParams {
+		end := min(start+maxBatchParams, len(ids))
+		batch := ids[start:end]
+
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+
+		rows, err := DB.Query(fmt.Sprintf(query, "?"+repeatString(",?", len(batch)-1)), args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// ListEntries retrieves a list of time tracking entries based on the provided [ListEntriesOptions] filters.
+// CountEntries returns the number of entries matching the filters in opts, ignoring opts.Limit.
 //
-// The function constructs a SQL query dynamically based on the filters in opts. These filters include project ID,
-// tag IDs, time range (From and To), and the maximum number of records to retrieve (Limit). It ensures only the
-// desired dataset is returned.
-//
-// The function populates additional details for each entry, such as the associated project, tags, and pauses.
-// Errors encountered in database interactions or data mapping are returned.
-//
-//   - opts: Filters for retrieving entries, including time range, project, tags, and result limit.
+// It applies the same filtering as [ListEntries] but only counts the rows, which makes it cheap to check the size of
+// a result before loading it.
 //
-// Returns:
-//   - A slice of [model.Entry] containing the relevant entries, or an error if something goes wrong.
-func ListEntries(opts ListEntriesOptions) ([]model.Entry, error) {
+// Returns the count, or an error if the query fails.
+func CountEntries(opts ListEntriesOptions) (int, error) {
+	where, args := entryFilter(opts)
 	query := `
-		SELECT DISTINCT e.id, e.project_id, e.title, e.start_time, e.end_time, e.status
+		SELECT COUNT(DISTINCT e.id)
 		FROM entries e
 		LEFT JOIN entry_tags et ON e.id = et.entry_id
-		WHERE 1=1`
+		WHERE ` + where
+
+	var count int
+	err := DB.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+// entryFilter builds the WHERE clause and its arguments for the filters in opts.
+//
+// The clause refers to the `entries` table as `e` and the `entry_tags` table as `et`. It never includes ordering or
+// limits, so it can be shared between [ListEntries] and [CountEntries].
+func entryFilter(opts ListEntriesOptions) (string, []interface{}) {
+	where := "1=1"
 	args := []interface{}{}
 
 	if opts.ProjectID != nil {
-		query += " AND e.project_id = ?"
+		where += " AND e.project_id = ?"
 		args = append(args, *opts.ProjectID)
 	}
 
 	if len(opts.TagIDs) > 0 {
-		query += " AND et.tag_id IN (?" + repeatString(",?", len(opts.TagIDs)-1) + ")"
-		for _, id := range opts.TagIDs {
+		tagIDs := slices.Compact(slices.Sorted(slices.Values(opts.TagIDs)))
+		where += " AND e.id IN (SELECT entry_id FROM entry_tags WHERE tag_id IN (?" + repeatString(",?", len(tagIDs)-1) + ")"
+		for _, id := range tagIDs {
 			args = append(args, id)
 		}
+		if opts.AnyTag {
+			where += ")"
+		} else {
+			// Require every tag, not just one of them
+			where += " GROUP BY entry_id HAVING COUNT(DISTINCT tag_id) = ?)"
+			args = append(args, len(tagIDs))
+		}
 	}
 
 	if opts.From != nil {
-		query += " AND e.start_time >= ?"
-		args = append(args, *opts.From)
+		where += " AND e.start_time >= ?"
+		args = append(args, opts.From.UTC())
 	}
 
 	if opts.To != nil {
-		query += " AND e.start_time < ?"
-		args = append(args, *opts.To)
+		where += " AND e.start_time < ?"
+		args = append(args, opts.To.UTC())
 	}
 
-	query += " ORDER BY e.start_time DESC"
-
-	if opts.Limit > 0 {
-		query += " LIMIT ?"
-		args = append(args, opts.Limit)
+	if opts.TitleSearch != nil {
+		// LIKE is case-insensitive for ASCII in SQLite; escape its wildcards so the search is a plain substring match
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(*opts.TitleSearch)
+		where += ` AND e.title LIKE ? ESCAPE '\'`
+		args = append(args, "%"+escaped+"%")
 	}
 
-	rows, err := DB.Query(query, args...)
-	if err != nil {
-		return nil, err
+	if opts.Status != nil {
+		where += " AND e.status = ?"
+		args = append(args, *opts.Status)
 	}
-	defer rows.Close()
 
-	var entries []model.Entry
-	for rows.Next() {
-		var e model.Entry
-		var endTime sql.NullTime
-		if err := rows.Scan(&e.ID, &e.ProjectID, &e.Title, &e.StartTime, &endTime, &e.Status); err != nil {
-			return nil, err
-		}
-		if endTime.Valid {
-			e.EndTime = &endTime.Time
-		}
-
-		project, err := GetProjectByID(e.ProjectID)
-		if err != nil {
-			return nil, err
-		}
-		e.Project = project
-
-		tags, err := GetTagsForEntry(e.ID)
-		if err != nil {
-			return nil, err
-		}
-		e.Tags = tags
+	if opts.Billable != nil {
+		where += " AND e.billable = ?"
+		args = append(args, *opts.Billable)
+	}
 
-		pauses, err := GetPausesForEntry(e.ID)
-		if err != nil {
-			return nil, err
+	if opts.Billed != nil {
+		if *opts.Billed {
+			where += " AND e.billed_at IS NOT NULL"
+		} else {
+			where += " AND e.billed_at IS NULL"
 		}
-		e.Pauses = pauses
+	}
 
-		entries = append(entries, e)
+	if opts.ExcludeArchived {
+		where += " AND e.project_id NOT IN (SELECT id FROM projects WHERE archived)"
 	}
 
-	return entries, rows.Err()
+	return where, args
 }
 
 // repeatString concatenates the string s, n times, and returns the resulting string.
@@ -706,17 +1851,25 @@ func GetPausesForEntry(entryID string) ([]model.Pause, error) {
 	for rows.Next() {
 		var p model.Pause
 		var resumeTime sql.NullTime
-		if err := rows.Scan(&p.ID, &p.EntryID, &p.PauseTime, &resumeTime, &p.Reason); err != nil {
+		if err := rows.Scan(&p.ID, &p.EntryID, localTime{&p.PauseTime}, &resumeTime, &p.Reason); err != nil {
 			return nil, err
 		}
 		if resumeTime.Valid {
-			p.ResumeTime = &resumeTime.Time
+			p.ResumeTime = localPtr(resumeTime)
 		}
 		pauses = append(pauses, p)
 	}
 	return pauses, rows.Err()
 }
 
+// HasOpenPause reports whether the entry with the given ID has a pause without a resume time. An entry may have at
+// most one, since its duration only subtracts the latest open pause.
+func HasOpenPause(entryID string) (bool, error) {
+	var open bool
+	err := DB.QueryRow("SELECT EXISTS (SELECT 1 FROM pauses WHERE entry_id = ? AND resume_time IS NULL)", entryID).Scan(&open)
+	return open, err
+}
+
 // DeletePause deletes a pause record from the database identified by the given id.
 //
 // The id parameter specifies the unique identifier of the pause to be deleted.
@@ -737,18 +1890,193 @@ func DeletePause(id string) error {
 // Returns an error if the database query fails, including cases such as connection issues or invalid SQL execution.
 func UpdatePause(id string, pauseTime time.Time, resumeTime *time.Time) error {
 	_, err := DB.Exec("UPDATE pauses SET pause_time = ?, resume_time = ? WHERE id = ?",
-		pauseTime, resumeTime, id)
+		pauseTime.UTC(), utcPtr(resumeTime), id)
 	return err
 }
 
+// CreatePause adds a pause to the entry with the given ID, from pauseTime to resumeTime, and returns the new pause's
+// ID. A nil resumeTime leaves the pause open; the entry's status is not changed.
+//
+// Returns an error if resumeTime is nil and the entry already has an open pause (see [HasOpenPause]), or if the
+// insert fails.
 func CreatePause(entryID string, pauseTime time.Time, resumeTime *time.Time, reason string) (string, error) {
+	if resumeTime == nil {
+		open, err := HasOpenPause(entryID)
+		if err != nil {
+			return "", err
+		}
+		if open {
+			return "", fmt.Errorf("entry already has an open pause")
+		}
+	}
+
 	pauseID := model.NewULID()
 	_, err := DB.Exec(
 		"INSERT INTO pauses (id, entry_id, pause_time, resume_time, reason) VALUES (?, ?, ?, ?, ?)",
-		pauseID, entryID, pauseTime, resumeTime, reason)
+		pauseID, entryID, pauseTime.UTC(), utcPtr(resumeTime), reason)
 	return pauseID, err
 }
 
+// Activity operations
+
+// UpdateActivity records the current time as the last user activity, as reported by `tally ping`.
+func UpdateActivity() error {
+	_, err := DB.Exec("UPDATE activity SET last_activity = ? WHERE id = 1", time.Now().UTC())
+	return err
+}
+
+// GetLastActivity returns the time of the last recorded user activity, or nil if none has been recorded.
+func GetLastActivity() (*time.Time, error) {
+	var last *time.Time
+	err := DB.QueryRow("SELECT last_activity FROM activity WHERE id = 1").Scan(nullLocalTime{&last})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return last, err
+}
+
+// Template operations
+
+// SaveTemplate stores a template named name that starts entries for the given project with title and tags. An existing
+// template with the same name is replaced.
+//
+// The template and its tags are written in one transaction.
+//
+// Returns an error if a database operation fails.
+func SaveTemplate(name, projectID, title string, tagIDs []string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM template_tags WHERE template_name = ?", name); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO templates (name, project_id, title) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET project_id = excluded.project_id, title = excluded.title`,
+		name, projectID, title)
+	if err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO template_tags (template_name, tag_id) VALUES (?, ?)", name, tagID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTemplate returns the template with the given name, including its project and tags, or nil if there is none.
+//
+// Returns an error if a database operation fails.
+func GetTemplate(name string) (*model.Template, error) {
+	var t model.Template
+	var projectID string
+	err := DB.QueryRow("SELECT name, project_id, COALESCE(title, '') FROM templates WHERE name = ?", name).
+		Scan(&t.Name, &projectID, &t.Title)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadTemplateRelations(&t, projectID); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListTemplates returns every template, including its project and tags, ordered by name.
+//
+// Returns an error if a database operation fails.
+func ListTemplates() ([]model.Template, error) {
+	rows, err := DB.Query("SELECT name, project_id, COALESCE(title, '') FROM templates ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []model.Template
+	var projectIDs []string
+	for rows.Next() {
+		var t model.Template
+		var projectID string
+		if err := rows.Scan(&t.Name, &projectID, &t.Title); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+		projectIDs = append(projectIDs, projectID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range templates {
+		if err := loadTemplateRelations(&templates[i], projectIDs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes the template with the given name and its tags.
+//
+// Returns whether a template was deleted, or an error if a database operation fails.
+func DeleteTemplate(name string) (bool, error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM template_tags WHERE template_name = ?", name); err != nil {
+		return false, err
+	}
+	result, err := tx.Exec("DELETE FROM templates WHERE name = ?", name)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, tx.Commit()
+}
+
+// loadTemplateRelations fills in the project with the given ID and the tags of t.
+func loadTemplateRelations(t *model.Template, projectID string) error {
+	project, err := GetProjectByID(projectID)
+	if err != nil {
+		return err
+	}
+	t.Project = project
+
+	rows, err := DB.Query(`
+		SELECT t.id, t.name, t.created_at
+		FROM tags t
+		JOIN template_tags tt ON tt.tag_id = t.id
+		WHERE tt.template_name = ?
+		ORDER BY t.name`, t.Name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag model.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, localTime{&tag.CreatedAt}); err != nil {
+			return err
+		}
+		t.Tags = append(t.Tags, tag)
+	}
+	return rows.Err()
+}
+
 // Config operations
 
 // GetConfig retrieves the configuration value associated with the given key from the database.
@@ -816,21 +2144,27 @@ func ListConfig() (map[string]string, error) {
 
 // GetProjectByName retrieves a project from the database by its name.
 //
-// It queries the "projects" table to find a project matching the specified name. If no project is found, it returns nil
-// without an error. If a database error occurs, it returns the error.
+// It queries the "projects" table to find a project matching the specified name, after resolving a project alias
+// with [ResolveProjectAlias]. If no project is found, it returns nil without an error. If a database error occurs, it
+// returns the error.
 //
 // The returned project includes fields such as ID, Name, and CreatedAt.
 //
 // Parameters:
-//   - name: The name of the project to look up.
+//   - name: The name or alias of the project to look up.
 //
 // Returns:
 //   - A pointer to the [model.Project] if found, or nil if no matching project exists.
 //   - An error if the database query fails, other than no rows found.
 func GetProjectByName(name string) (*model.Project, error) {
+	name, err := ResolveProjectAlias(name)
+	if err != nil {
+		return nil, err
+	}
+
 	var p model.Project
-	err := DB.QueryRow("SELECT id, name, created_at FROM projects WHERE name = ?", name).
-		Scan(&p.ID, &p.Name, &p.CreatedAt)
+	err = DB.QueryRow("SELECT id, name, archived, created_at FROM projects WHERE name = ?", name).
+		Scan(&p.ID, &p.Name, &p.Archived, localTime{&p.CreatedAt})
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -858,7 +2192,7 @@ func GetProjectByName(name string) (*model.Project, error) {
 func GetTagByName(name string) (*model.Tag, error) {
 	var t model.Tag
 	err := DB.QueryRow("SELECT id, name, created_at FROM tags WHERE name = ?", name).
-		Scan(&t.ID, &t.Name, &t.CreatedAt)
+		Scan(&t.ID, &t.Name, localTime{&t.CreatedAt})
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -867,3 +2201,102 @@ func GetTagByName(name string) (*model.Tag, error) {
 	}
 	return &t, nil
 }
+
+// RenameProject changes the name of the project with the given id to newName.
+//
+// Entries reference projects by ID, so they reflect the new name without being rewritten. The project's hourly rate,
+// which is keyed by name, moves to the new name.
+//
+// Returns an error if another project or a project alias is already named newName, or if the update fails.
+func RenameProject(id, newName string) error {
+	var count int
+	if err := DB.QueryRow("SELECT COUNT(*) FROM project_aliases WHERE alias = ?", newName).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("@%s is a project alias (remove it with 'tally alias delete @%s')", newName, newName)
+	}
+	return renameRow("projects", "project @", id, newName, func(tx *sql.Tx, oldName string) error {
+		_, err := tx.Exec("UPDATE OR REPLACE config SET key = ? WHERE key = ?", rateKeyPrefix+newName, rateKeyPrefix+oldName)
+		return err
+	})
+}
+
+// RenameTag changes the name of the tag with the given id to newName.
+//
+// Entries reference tags by ID through `entry_tags`, so they reflect the new name without being rewritten.
+//
+// Returns an error if another tag is already named newName, or if the update fails.
+func RenameTag(id, newName string) error {
+	return renameRow("tags", "tag +", id, newName, nil)
+}
+
+// renameRow updates the `name` column of a row in table, checking the UNIQUE constraint up front so that a clash
+// produces a readable error instead of a SQL constraint failure. label prefixes the name in that error.
+//
+// If rekey is not nil, it is called with the row's previous name in the same transaction, to update anything else
+// keyed by the name.
+func renameRow(table, label, id, newName string, rekey func(tx *sql.Tx, oldName string) error) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE name = ? AND id != ?", newName, id).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%s%s already exists", label, newName)
+	}
+
+	var oldName string
+	err = tx.QueryRow("SELECT name FROM "+table+" WHERE id = ?", id).Scan(&oldName)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE "+table+" SET name = ? WHERE id = ?", newName, id); err != nil {
+		return err
+	}
+	if rekey != nil && oldName != newName {
+		if err := rekey(tx, oldName); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListProjectNames returns the names of all projects that are not archived, in alphabetical order.
+func ListProjectNames() ([]string, error) {
+	return listNames("SELECT name FROM projects WHERE NOT archived ORDER BY name")
+}
+
+// ListTagNames returns the names of all tags in alphabetical order.
+func ListTagNames() ([]string, error) {
+	return listNames("SELECT name FROM tags ORDER BY name")
+}
+
+// listNames runs a query selecting a single text column and returns its values.
+func listNames(query string) ([]string, error) {
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
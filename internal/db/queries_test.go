@@ -0,0 +1,490 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/model"
+)
+
+func TestGetOrCreateConcurrent(t *testing.T) {
+	openTestDB(t)
+
+	const workers = 16
+	tests := []struct {
+		name   string
+		table  string
+		create func() (string, error)
+	}{
+		{"project", "projects", func() (string, error) {
+			p, err := GetOrCreateProject("work")
+			if err != nil {
+				return "", err
+			}
+			return p.ID, nil
+		}},
+		{"tag", "tags", func() (string, error) {
+			tag, err := GetOrCreateTag("meeting")
+			if err != nil {
+				return "", err
+			}
+			return tag.ID, nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]string, workers)
+			errs := make([]error, workers)
+			var wg sync.WaitGroup
+			for i := range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ids[i], errs[i] = tt.create()
+				}()
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("worker %d: error = %v", i, err)
+				}
+				if ids[i] != ids[0] {
+					t.Errorf("worker %d: id = %s, want %s", i, ids[i], ids[0])
+				}
+			}
+
+			var count int
+			if err := DB.QueryRow("SELECT COUNT(*) FROM " + tt.table).Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("%s rows = %d, want 1", tt.table, count)
+			}
+		})
+	}
+}
+
+func TestSplitEntryCopiesFields(t *testing.T) {
+	openTestDB(t)
+
+	project, err := GetOrCreateProject("client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := GetOrCreateTag("design")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	entry, err := CreateImportedEntry(project.ID, "Mockups", []string{tag.ID}, start, start.Add(2*time.Hour), "toggl:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetEntryNote(entry.ID, "Round two"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetEntryBillable(entry.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	billedAt := time.Date(2024, 3, 31, 17, 0, 0, 0, time.UTC)
+	if err := MarkBilled([]string{entry.ID}, billedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := SplitEntry(entry.ID, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SplitEntry() error = %v", err)
+	}
+
+	if second.ProjectID != project.ID || second.Title != "Mockups" || second.Note != "Round two" {
+		t.Errorf("second half = %s %q %q, want %s %q %q",
+			second.ProjectID, second.Title, second.Note, project.ID, "Mockups", "Round two")
+	}
+	if second.Billable {
+		t.Error("second half is billable, want not billable")
+	}
+	if second.BilledAt == nil || !second.BilledAt.Equal(billedAt) {
+		t.Errorf("second half billed at %v, want %v", second.BilledAt, billedAt)
+	}
+	if len(second.Tags) != 1 || second.Tags[0].ID != tag.ID {
+		t.Errorf("second half tags = %v, want [%s]", second.Tags, tag.Name)
+	}
+	if second.SourceID != "toggl:42" {
+		t.Errorf("second half SourceID = %q, want %q", second.SourceID, "toggl:42")
+	}
+	if !second.StartTime.Equal(start.Add(time.Hour)) || !second.EndTime.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("second half = %v - %v, want %v - %v",
+			second.StartTime, second.EndTime, start.Add(time.Hour), start.Add(2*time.Hour))
+	}
+}
+
+func TestRestoreEntryKeepsSourceID(t *testing.T) {
+	openTestDB(t)
+
+	project, err := GetOrCreateProject("client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	imported, err := CreateImportedEntry(project.ID, "Mockups", nil, start, end, "clockify:7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.SourceID != "clockify:7" {
+		t.Fatalf("imported SourceID = %q, want %q", imported.SourceID, "clockify:7")
+	}
+
+	// Export the entry as JSON and restore it in place, as `tally export` and `tally import` do
+	data, err := json.Marshal(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported model.Entry
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteEntry(imported.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := RestoreEntry(&exported); err != nil {
+		t.Fatalf("RestoreEntry() error = %v", err)
+	}
+
+	restored, err := GetEntryByID(imported.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.SourceID != "clockify:7" {
+		t.Errorf("restored SourceID = %q, want %q", restored.SourceID, "clockify:7")
+	}
+	found, err := HasEntryWithSourceID("clockify:7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Error("HasEntryWithSourceID() = false after restore, want true")
+	}
+}
+
+// seedEntries creates n stopped entries of 30 minutes each, one per hour from start, spread over three projects, each
+// with two tags and a pause.
+func seedEntries(tb testing.TB, n int, start time.Time) {
+	tb.Helper()
+	var projectIDs, tagIDs []string
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		p, err := GetOrCreateProject(name)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		projectIDs = append(projectIDs, p.ID)
+		tag, err := GetOrCreateTag(name)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	for i := range n {
+		entryStart := start.Add(time.Duration(i) * time.Hour)
+		e, err := CreateCompletedEntry(projectIDs[i%3], "Task", []string{tagIDs[i%3], tagIDs[(i+1)%3]},
+			entryStart, entryStart.Add(30*time.Minute))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		resume := entryStart.Add(10 * time.Minute)
+		if _, err := CreatePause(e.ID, entryStart.Add(5*time.Minute), &resume, "Manual"); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func TestListEntriesQueryCount(t *testing.T) {
+	openTestDB(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seedEntries(t, 50, start)
+	queries := countQueries(t)
+
+	// One query for the entries, then one each for their projects, tags, and pauses
+	const want = 4
+	tests := []struct {
+		name string
+		load func() ([]model.Entry, error)
+	}{
+		{"ListEntries", func() ([]model.Entry, error) { return ListEntries(ListEntriesOptions{}) }},
+		{"EntriesOverlapping", func() ([]model.Entry, error) { return EntriesOverlapping(start, start.Add(50*time.Hour)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries.Store(0)
+			entries, err := tt.load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 50 {
+				t.Fatalf("got %d entries, want 50", len(entries))
+			}
+			for _, e := range entries {
+				if e.Project == nil || len(e.Tags) != 2 || len(e.Pauses) != 1 {
+					t.Fatalf("entry %s not fully loaded: project %v, %d tags, %d pauses",
+						e.ID, e.Project, len(e.Tags), len(e.Pauses))
+				}
+			}
+			if got := queries.Load(); got != want {
+				t.Errorf("ran %d queries, want %d", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkListEntries(b *testing.B) {
+	openTestDB(b)
+	seedEntries(b, 1000, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	for b.Loop() {
+		entries, err := ListEntries(ListEntriesOptions{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(entries) != 1000 {
+			b.Fatalf("got %d entries, want 1000", len(entries))
+		}
+	}
+}
+
+func TestCountActiveEntries(t *testing.T) {
+	openTestDB(t)
+
+	project, err := GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, timer := range []string{"", "deep", "deep", "review"} {
+		if _, err := CreateTimerEntry(project.ID, "Task", nil, timer); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stopped, err := CreateTimerEntry(project.ID, "Done", nil, "review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := StopEntry(stopped.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := CountActiveEntries()
+	if err != nil {
+		t.Fatalf("CountActiveEntries() error = %v", err)
+	}
+	want := map[string]int{"": 1, "deep": 2, "review": 1}
+	if !maps.Equal(counts, want) {
+		t.Errorf("CountActiveEntries() = %v, want %v", counts, want)
+	}
+}
+
+func TestListEntriesTagFilter(t *testing.T) {
+	openTestDB(t)
+
+	project, err := GetOrCreateProject("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend, err := GetOrCreateTag("backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	urgent, err := GetOrCreateTag("urgent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	for i, e := range []struct {
+		title string
+		tags  []string
+	}{
+		{"both", []string{backend.ID, urgent.ID}},
+		{"backend only", []string{backend.ID}},
+		{"urgent only", []string{urgent.ID}},
+		{"untagged", nil},
+	} {
+		entryStart := start.Add(time.Duration(i) * time.Hour)
+		if _, err := CreateCompletedEntry(project.ID, e.title, e.tags, entryStart, entryStart.Add(30*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tagIDs []string
+		anyTag bool
+		want   []string
+	}{
+		{"all tags", []string{backend.ID, urgent.ID}, false, []string{"both"}},
+		{"any tag", []string{backend.ID, urgent.ID}, true, []string{"backend only", "both", "urgent only"}},
+		{"one tag", []string{backend.ID}, false, []string{"backend only", "both"}},
+		{"repeated tag", []string{backend.ID, backend.ID}, false, []string{"backend only", "both"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ListEntries(ListEntriesOptions{TagIDs: tt.tagIDs, AnyTag: tt.anyTag})
+			if err != nil {
+				t.Fatalf("ListEntries() error = %v", err)
+			}
+			var titles []string
+			for _, e := range entries {
+				titles = append(titles, e.Title)
+			}
+			slices.Sort(titles)
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("ListEntries() = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestEachEntry(t *testing.T) {
+	openTestDB(t)
+	seedEntries(t, 20, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	pageSize := entryPageSize
+	entryPageSize = 7
+	t.Cleanup(func() { entryPageSize = pageSize })
+
+	tests := []struct {
+		name string
+		opts ListEntriesOptions
+	}{
+		{"all", ListEntriesOptions{}},
+		{"sorted with ties", ListEntriesOptions{SortBy: "title", Ascending: true}},
+		{"limit across pages", ListEntriesOptions{Limit: 10}},
+		{"limit of one page", ListEntriesOptions{Limit: 7}},
+		{"offset", ListEntriesOptions{Offset: 5}},
+		{"offset and limit", ListEntriesOptions{Offset: 3, Limit: 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := ListEntries(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []model.Entry
+			if err := EachEntry(tt.opts, func(e model.Entry) error {
+				got = append(got, e)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("got %d entries, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i].ID != want[i].ID {
+					t.Fatalf("entry %d is %s, want %s", i, got[i].ID, want[i].ID)
+				}
+				if got[i].Project == nil || len(got[i].Tags) != 2 || len(got[i].Pauses) != 1 {
+					t.Fatalf("entry %s not fully loaded", got[i].ID)
+				}
+			}
+		})
+	}
+
+	t.Run("stops on error", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := EachEntry(ListEntriesOptions{}, func(model.Entry) error {
+			if calls++; calls == 9 {
+				return stop
+			}
+			return nil
+		})
+		if !errors.Is(err, stop) {
+			t.Fatalf("got error %v, want %v", err, stop)
+		}
+		if calls != 9 {
+			t.Errorf("fn called %d times, want 9", calls)
+		}
+	})
+}
+
+func TestRateFollowsProject(t *testing.T) {
+	openTestDB(t)
+
+	rate := func(name string) string {
+		t.Helper()
+		value, err := GetConfig(rateKeyPrefix + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	old, err := GetOrCreateProject("old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetConfig(rateKeyPrefix+"old", "100"); err != nil {
+		t.Fatal(err)
+	}
+	// A rate left behind by a project that no longer exists under this name is replaced
+	if err := SetConfig(rateKeyPrefix+"new", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RenameProject(old.ID, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if got := rate("new"); got != "100" {
+		t.Errorf("rate of renamed project = %q, want 100", got)
+	}
+	if got := rate("old"); got != "" {
+		t.Errorf("rate under the old name = %q, want it removed", got)
+	}
+
+	t.Run("merge into a project without a rate", func(t *testing.T) {
+		dest, err := GetOrCreateProject("dest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MergeProjects(old.ID, dest.ID); err != nil {
+			t.Fatal(err)
+		}
+		if got := rate("dest"); got != "100" {
+			t.Errorf("rate of merged project = %q, want 100", got)
+		}
+		if got := rate("new"); got != "" {
+			t.Errorf("rate of the source = %q, want it removed", got)
+		}
+	})
+
+	t.Run("merge into a project with a rate", func(t *testing.T) {
+		source, err := GetOrCreateProject("source")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := SetConfig(rateKeyPrefix+"source", "50"); err != nil {
+			t.Fatal(err)
+		}
+		dest, err := GetProjectByName("dest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MergeProjects(source.ID, dest.ID); err != nil {
+			t.Fatal(err)
+		}
+		if got := rate("dest"); got != "100" {
+			t.Errorf("rate of merged project = %q, want its own 100", got)
+		}
+		if got := rate("source"); got != "" {
+			t.Errorf("rate of the source = %q, want it removed", got)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the number of names returned by [SuggestProject] and [SuggestTag].
+const maxSuggestions = 3
+
+// SuggestProject returns the names of up to three projects, including archived ones, that are closest to name. It is
+// meant for "did you mean" hints after a lookup by name found nothing.
+//
+// Returns the names ordered from closest to farthest, or an error if listing the projects fails.
+func SuggestProject(name string) ([]string, error) {
+	names, err := listNames("SELECT name FROM projects ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	return closestNames(name, names), nil
+}
+
+// SuggestTag returns the names of up to three tags that are closest to name, like [SuggestProject].
+func SuggestTag(name string) ([]string, error) {
+	names, err := ListTagNames()
+	if err != nil {
+		return nil, err
+	}
+	return closestNames(name, names), nil
+}
+
+// closestNames returns the candidates within a small edit distance of name, closest first and then alphabetically.
+//
+// Names are compared case-insensitively. The allowed distance grows with the length of name: two edits, or a third of
+// its length for longer names, so short typos match without every short name matching.
+func closestNames(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	target := strings.ToLower(name)
+	maxDistance := max(2, len([]rune(target))/3)
+
+	var matches []match
+	for _, c := range candidates {
+		if d := levenshtein(target, strings.ToLower(c)); d <= maxDistance {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	names := make([]string, 0, min(len(matches), maxSuggestions))
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// levenshtein returns the number of single-rune insertions, deletions, and substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,91 @@
+// Package idle detects periods without user activity and records them as pauses on the running timer.
+//
+// Activity is reported by `tally ping`, typically from a shell prompt or editor hook. Detection is off unless
+// `idle.threshold` is set.
+package idle
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thinktide/tally/internal/config"
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// Reason is recorded as the reason of idle pauses.
+const Reason = "Idle"
+
+// IdlePeriod is a span of time without any recorded activity, recorded as a pause on an entry of Project.
+type IdlePeriod struct {
+	Start   time.Time
+	End     time.Time
+	Project string
+}
+
+// Duration returns the length of the idle period.
+func (p IdlePeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// CheckAndHandleIdle records the time since the last activity as a pause on each running timer when it exceeds the
+// configured `idle.threshold`.
+//
+// As with sleep detection, only running entries are considered, on the default timer and on every named timer. For
+// each, the idle period starts no earlier than the later of the entry's start and the end of its most recent pause, so
+// time that is already excluded is not paused twice. The pause ends now.
+//
+// Nothing is printed, so callers can decide how (and whether) to report the result. Activity is not updated; callers
+// that represent user activity should call [db.UpdateActivity] afterwards.
+//
+// Returns the recorded periods, one per paused entry, or an error if the configuration or database cannot be read.
+// Periods recorded before an error are returned with it.
+func CheckAndHandleIdle() ([]IdlePeriod, error) {
+	threshold, err := config.IdleThreshold()
+	if err != nil || threshold == 0 {
+		return nil, err
+	}
+
+	last, err := db.GetLastActivity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last activity: %w", err)
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	entries, err := db.ListActiveEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active entries: %w", err)
+	}
+
+	now := db.Now()
+	var periods []IdlePeriod
+	for _, entry := range entries {
+		if entry.Status != model.StatusRunning {
+			continue
+		}
+
+		since := entry.StartTime
+		for _, p := range entry.Pauses {
+			if p.ResumeTime != nil && p.ResumeTime.After(since) {
+				since = *p.ResumeTime
+			}
+		}
+		if last.After(since) {
+			since = *last
+		}
+
+		period := IdlePeriod{Start: since, End: now, Project: entry.Project.Name}
+		if period.Duration() < threshold {
+			continue
+		}
+
+		end := period.End
+		if _, err := db.CreatePause(entry.ID, period.Start, &end, Reason); err != nil {
+			return periods, fmt.Errorf("failed to create pause: %w", err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, nil
+}
@@ -15,6 +15,7 @@ func NewULID() string {
 type Project struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
+	Archived  bool      `json:"archived,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -33,18 +34,28 @@ const (
 )
 
 type Entry struct {
-	ID        string      `json:"id"`
-	ProjectID string      `json:"project_id"`
-	Project   *Project    `json:"project,omitempty"`
-	Title     string      `json:"title"`
-	StartTime time.Time   `json:"start_time"`
-	EndTime   *time.Time  `json:"end_time,omitempty"`
-	Status    EntryStatus `json:"status"`
-	Tags      []Tag       `json:"tags,omitempty"`
-	Pauses    []Pause     `json:"pauses,omitempty"`
+	ID         string         `json:"id"`
+	ProjectID  string         `json:"project_id"`
+	Project    *Project       `json:"project,omitempty"`
+	Title      string         `json:"title"`
+	Note       string         `json:"note,omitempty"`
+	Timer      string         `json:"timer,omitempty"`
+	SourceID   string         `json:"source_id,omitempty"`
+	Billable   bool           `json:"billable"`
+	BilledAt   *time.Time     `json:"billed_at,omitempty"`
+	Estimate   *time.Duration `json:"estimate,omitempty"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    *time.Time     `json:"end_time,omitempty"`
+	PlannedEnd *time.Time     `json:"planned_end,omitempty"`
+	Status     EntryStatus    `json:"status"`
+	Tags       []Tag          `json:"tags,omitempty"`
+	Pauses     []Pause        `json:"pauses,omitempty"`
 }
 
-// Duration calculates the actual working duration excluding pauses
+// Duration calculates the actual working duration excluding pauses.
+//
+// Only the most recent open pause of a paused entry is subtracted, so stale open pauses left behind by a crash are
+// not counted twice. The result is never negative.
 func (e *Entry) Duration() time.Duration {
 	endTime := time.Now()
 	if e.EndTime != nil {
@@ -54,16 +65,20 @@ func (e *Entry) Duration() time.Duration {
 	total := endTime.Sub(e.StartTime)
 
 	// Subtract pause durations
-	for _, p := range e.Pauses {
+	var openPause *Pause
+	for i, p := range e.Pauses {
 		if p.ResumeTime != nil {
 			total -= p.ResumeTime.Sub(p.PauseTime)
-		} else if e.Status == StatusPaused {
-			// Currently paused, subtract time from pause start to now
-			total -= time.Since(p.PauseTime)
+		} else if openPause == nil || p.PauseTime.After(openPause.PauseTime) {
+			openPause = &e.Pauses[i]
 		}
 	}
+	if openPause != nil && e.Status == StatusPaused {
+		// Currently paused, subtract time from pause start to now
+		total -= endTime.Sub(openPause.PauseTime)
+	}
 
-	return total
+	return max(total, 0)
 }
 
 type Pause struct {
@@ -82,6 +97,20 @@ func (p *Pause) Duration() time.Duration {
 	return time.Since(p.PauseTime)
 }
 
+// Template is a saved project, title, and set of tags that `tally start --template` starts an entry with.
+type Template struct {
+	Name    string   `json:"name"`
+	Project *Project `json:"project"`
+	Title   string   `json:"title,omitempty"`
+	Tags    []Tag    `json:"tags,omitempty"`
+}
+
+// ProjectAlias is a short name that `@alias` arguments resolve to the named project, set with `tally alias`.
+type ProjectAlias struct {
+	Alias   string `json:"alias"`
+	Project string `json:"project"`
+}
+
 type EntryTag struct {
 	EntryID string `json:"entry_id"`
 	TagID   string `json:"tag_id"`
@@ -95,13 +124,78 @@ type ReportEntry struct {
 	Duration    time.Duration `json:"duration"`
 }
 
-// ReportSummary contains aggregated report data
+// ReportSummary contains aggregated report data.
+//
+// ByDay is keyed by the entry's start date in `2006-01-02` format. It only contains days with tracked time unless
+// zero days were requested to be filled in.
+//
+// ByTagSet is only populated when grouping by tag combination. It is keyed by the entry's sorted tag names joined
+// with commas, with untagged entries under the empty key, so its values always add up to TotalDuration.
+//
+// ByTagPrefix is only populated when grouping by tag prefix. It maps each prefix (e.g. "client" for "client:acme") to
+// the durations of its values (e.g. "acme"). Tags without a separator are listed under their full name with an
+// empty value.
+//
+// ByPauseReason is only populated when grouping by pause reason. It maps each pause reason, such as "lunch" or
+// "meeting", to the time the reported entries spent paused for it, with open pauses closed at the entry's end, the
+// period end, or now, whichever applies. Pause time is not part of TotalDuration.
+//
+// RawTotal is the sum of the entries' durations as recorded, and AdjustedTotal is the sum after report adjustments
+// such as clamping open entries to the period end; it always equals TotalDuration. AdjustmentReason describes the
+// adjustments that changed any duration, and is empty when RawTotal and AdjustedTotal agree.
+//
+// ByProjectAmount holds the billable amount of each project that has an hourly rate, computed from the adjusted
+// durations of its billable entries; projects without a rate are absent rather than zero. TotalAmount is their sum,
+// in Currency.
+//
+// BillableDuration and NonBillableDuration split TotalDuration by the entries' billable flag. BilledDuration and
+// UnbilledDuration split it by whether the entries have been marked billed; billed entries are only reported on request.
+//
+// EstimatedDuration is the sum of the estimates of the entries that have one, and EstimatedActual the sum of those
+// same entries' durations, so their difference is the variance; entries without an estimate count towards neither.
+//
+// ActiveEntries counts the reported entries that are still running or paused and were measured up to AsOf, the time
+// the report was generated, so their durations grow each time the report is run. Open entries clamped to the end of a
+// past period are not counted, and AsOf is nil when there are none.
+//
+// GroupBy and Groups are only set when a single headline grouping was requested ("day", "week", "project", or
+// "tag"). Groups is keyed by the day or week start date in `2006-01-02` format, or by the project or tag name.
 type ReportSummary struct {
-	TotalDuration    time.Duration            `json:"total_duration"`
-	ByProject        map[string]time.Duration `json:"by_project"`
-	ByTag            map[string]time.Duration `json:"by_tag"`
-	Entries          []ReportEntry            `json:"entries"`
-	Period           string                   `json:"period"`
-	StartDate        time.Time                `json:"start_date"`
-	EndDate          time.Time                `json:"end_date"`
+	TotalDuration    time.Duration                       `json:"total_duration"`
+	ByProject        map[string]time.Duration            `json:"by_project"`
+	ByTag            map[string]time.Duration            `json:"by_tag"`
+	ByDay            map[string]time.Duration            `json:"by_day"`
+	ByTagSet         map[string]time.Duration            `json:"by_tag_set,omitempty"`
+	ByTagPrefix      map[string]map[string]time.Duration `json:"by_tag_prefix,omitempty"`
+	ByPauseReason    map[string]time.Duration            `json:"by_pause_reason,omitempty"`
+	GroupBy          string                              `json:"group_by,omitempty"`
+	Groups           map[string]time.Duration            `json:"groups,omitempty"`
+	Entries          []ReportEntry                       `json:"entries"`
+	Period           string                              `json:"period"`
+	StartDate        time.Time                           `json:"start_date"`
+	EndDate          time.Time                           `json:"end_date"`
+	RawTotal         time.Duration                       `json:"raw_total"`
+	AdjustedTotal    time.Duration                       `json:"adjusted_total"`
+	AdjustmentReason string                              `json:"adjustment_reason,omitempty"`
+	ByProjectAmount  map[string]float64                  `json:"by_project_amount,omitempty"`
+	TotalAmount      float64                             `json:"total_amount,omitempty"`
+	Currency         string                              `json:"currency,omitempty"`
+
+	BillableDuration    time.Duration `json:"billable_duration"`
+	NonBillableDuration time.Duration `json:"non_billable_duration"`
+
+	BilledDuration   time.Duration `json:"billed_duration"`
+	UnbilledDuration time.Duration `json:"unbilled_duration"`
+
+	EstimatedDuration time.Duration `json:"estimated_duration,omitempty"`
+	EstimatedActual   time.Duration `json:"estimated_actual,omitempty"`
+
+	ActiveEntries int        `json:"active_entries,omitempty"`
+	AsOf          *time.Time `json:"as_of,omitempty"`
+}
+
+// Adjusted reports whether any report adjustment changed the durations, in which case the reconciliation between
+// RawTotal and AdjustedTotal should be shown.
+func (s *ReportSummary) Adjusted() bool {
+	return s.AdjustmentReason != ""
 }
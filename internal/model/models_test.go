@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryDuration(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) time.Time { return now.Add(-ago) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name  string
+		entry Entry
+		want  time.Duration
+	}{
+		{
+			name: "paused with one closed and one open pause",
+			entry: Entry{
+				StartTime: at(3 * time.Hour),
+				Status:    StatusPaused,
+				Pauses: []Pause{
+					{PauseTime: at(150 * time.Minute), ResumeTime: ptr(at(2 * time.Hour))},
+					{PauseTime: at(time.Hour)},
+				},
+			},
+			// 3h minus the 30m closed pause minus the 1h open one
+			want: 90 * time.Minute,
+		},
+		{
+			name: "stale open pause is subtracted once",
+			entry: Entry{
+				StartTime: at(3 * time.Hour),
+				Status:    StatusPaused,
+				Pauses: []Pause{
+					{PauseTime: at(2 * time.Hour)},
+					{PauseTime: at(time.Hour)},
+				},
+			},
+			want: 2 * time.Hour,
+		},
+		{
+			name: "stopped 2h entry with a 30m pause",
+			entry: Entry{
+				StartTime: at(3 * time.Hour),
+				EndTime:   ptr(at(time.Hour)),
+				Status:    StatusStopped,
+				Pauses: []Pause{
+					{PauseTime: at(2 * time.Hour), ResumeTime: ptr(at(90 * time.Minute))},
+				},
+			},
+			want: 90 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.entry.Duration()
+			// Open entries are measured up to the time of the call
+			if diff := got - tt.want; diff < 0 || diff > time.Second {
+				t.Errorf("Duration() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// Gap represents a span of untracked time between two consecutive entries.
+type Gap struct {
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+// FindGaps returns the untracked spans between consecutive entries within the given period.
+//
+// Entries are sorted by start time and walked in order, tracking the latest end time seen so far so that overlapping
+// entries don't produce false gaps. Running or paused entries are treated as ending now. Only gaps that fall within
+// a single calendar day are reported, so the time between the last entry of one day and the first entry of the next
+// is not counted as untracked work time.
+//
+//   - period: The [Period] to search for gaps.
+//   - minimum: Gaps shorter than this duration are ignored.
+//
+// Returns the gaps in chronological order, or an error if the entries cannot be loaded.
+func FindGaps(period Period, minimum time.Duration) ([]Gap, error) {
+	start, end := GetPeriodDateRange(period)
+
+	entries, err := db.ListEntries(db.ListEntriesOptions{From: &start, To: &end})
+	if err != nil {
+		return nil, err
+	}
+
+	return findGaps(entries, minimum), nil
+}
+
+// findGaps computes the gaps between the given entries. See [FindGaps].
+func findGaps(entries []model.Entry, minimum time.Duration) []Gap {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].StartTime.Before(entries[j].StartTime)
+	})
+
+	var gaps []Gap
+	var lastEnd time.Time
+	for _, e := range entries {
+		if !lastEnd.IsZero() && e.StartTime.After(lastEnd) && sameDay(lastEnd, e.StartTime) {
+			if d := e.StartTime.Sub(lastEnd); d >= minimum {
+				gaps = append(gaps, Gap{Start: lastEnd, End: e.StartTime, Duration: d})
+			}
+		}
+
+		entryEnd := db.Now()
+		if e.EndTime != nil {
+			entryEnd = *e.EndTime
+		}
+		if entryEnd.After(lastEnd) {
+			lastEnd = entryEnd
+		}
+	}
+
+	return gaps
+}
+
+// sameDay reports whether a and b fall on the same calendar day.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/thinktide/tally/internal/config"
 	"github.com/thinktide/tally/internal/db"
 	"github.com/thinktide/tally/internal/model"
 )
@@ -31,73 +35,203 @@ var AllPeriods = []Period{
 	PeriodLastYear,
 }
 
+// IsValidPeriod reports whether p is one of the periods in [AllPeriods].
+func IsValidPeriod(p Period) bool {
+	for _, valid := range AllPeriods {
+		if p == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPeriodDateRange returns the half-open time range of period as of now, in [db.Location], with weeks starting on the
+// day set by [config.KeyReportWeekStart]. Monday is used if the setting cannot be read.
 func GetPeriodDateRange(period Period) (start, end time.Time) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	weekStart, _ := config.WeekStart()
+	return PeriodDateRange(period, db.Now(), weekStart)
+}
+
+// PeriodDateRange returns the half-open time range of period as of now, in the time zone of now.
+//
+// The week and lastWeek periods start on weekStart. Ranges of periods in progress, like week, end at the end of today
+// rather than the end of the period.
+func PeriodDateRange(period Period, now time.Time, weekStart time.Weekday) (start, end time.Time) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	// Days since the start of the week, 0 to 6
+	daysIntoWeek := (int(today.Weekday()) - int(weekStart) + 7) % 7
 
 	switch period {
 	case PeriodToday:
 		start = today
-		end = today.Add(24 * time.Hour)
+		end = today.AddDate(0, 0, 1)
 
 	case PeriodYesterday:
-		start = today.Add(-24 * time.Hour)
+		start = today.AddDate(0, 0, -1)
 		end = today
 
 	case PeriodWeek:
-		// Start of this week (Monday)
-		weekday := int(today.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		}
-		start = today.Add(-time.Duration(weekday-1) * 24 * time.Hour)
-		end = today.Add(24 * time.Hour)
+		start = today.AddDate(0, 0, -daysIntoWeek)
+		end = today.AddDate(0, 0, 1)
 
 	case PeriodLastWeek:
-		weekday := int(today.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		}
-		thisWeekStart := today.Add(-time.Duration(weekday-1) * 24 * time.Hour)
-		start = thisWeekStart.Add(-7 * 24 * time.Hour)
+		thisWeekStart := today.AddDate(0, 0, -daysIntoWeek)
+		start = thisWeekStart.AddDate(0, 0, -7)
 		end = thisWeekStart
 
 	case PeriodMonth:
-		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
-		end = today.Add(24 * time.Hour)
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		end = today.AddDate(0, 0, 1)
 
 	case PeriodLastMonth:
-		firstOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		firstOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
 		start = firstOfThisMonth.AddDate(0, -1, 0)
 		end = firstOfThisMonth
 
 	case PeriodYear:
-		start = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local)
-		end = today.Add(24 * time.Hour)
+		start = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, loc)
+		end = today.AddDate(0, 0, 1)
 
 	case PeriodLastYear:
-		start = time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, time.Local)
-		end = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, loc)
+		end = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, loc)
 	}
 
 	return start, end
 }
 
+// DayKeyFormat is the layout of the keys in [model.ReportSummary.ByDay].
+const DayKeyFormat = "2006-01-02"
+
+// GroupBy selects an additional breakdown to compute in [GenerateReport].
+type GroupBy string
+
+const (
+	// GroupByNone computes only the default per-project and per-tag breakdowns.
+	GroupByNone GroupBy = ""
+	// GroupByTagSet groups entries by their exact combination of tags.
+	GroupByTagSet GroupBy = "tagset"
+	// GroupByTagPrefix groups tags by the part before [ReportOptions.TagSeparator], with per-value subtotals.
+	GroupByTagPrefix GroupBy = "tag-prefix"
+	// GroupByPauseReason totals the time entries spent paused by the reason of each pause.
+	GroupByPauseReason GroupBy = "pause-reason"
+	// GroupByDay makes the per-day totals the report's only breakdown.
+	GroupByDay GroupBy = "day"
+	// GroupByWeek makes per-week totals, keyed by the first day of each week, the report's only breakdown.
+	GroupByWeek GroupBy = "week"
+	// GroupByProject makes the per-project totals the report's only breakdown.
+	GroupByProject GroupBy = "project"
+	// GroupByTag makes the per-tag totals the report's only breakdown.
+	GroupByTag GroupBy = "tag"
+)
+
+// AllGroupBys lists the accepted non-default [GroupBy] values.
+var AllGroupBys = []GroupBy{
+	GroupByTagSet,
+	GroupByTagPrefix,
+	GroupByPauseReason,
+	GroupByDay,
+	GroupByWeek,
+	GroupByProject,
+	GroupByTag,
+}
+
+// IsHeadline reports whether g replaces the default breakdowns with a single headline grouping, stored in
+// [model.ReportSummary.Groups].
+func (g GroupBy) IsHeadline() bool {
+	return g == GroupByDay || g == GroupByWeek || g == GroupByProject || g == GroupByTag
+}
+
+// ReportOptions selects the entries and breakdowns computed by [GenerateReport].
+//
+// ProjectID and TagIDs hold ULIDs, matching [db.ListEntriesOptions], and are passed to [db.ListEntries] unchanged, as
+// are AnyTag and Billable.
+//
+// Rounding, when positive, rounds each entry's duration up to a multiple of it before aggregation.
+//
+// Rates maps project names to hourly rates used to compute billable amounts, shown in Currency. Only billable entries
+// are charged.
+//
+// Entries already marked billed are left out unless IncludeBilled is set.
+//
+// Per-day totals count each entry on the day it started, unless SplitMidnight is set, in which case an entry spanning
+// midnight is split across the calendar days it covers within the report range (see [SplitByDay]).
+//
+// Running and paused entries are left out when ExcludeActive is set; otherwise they are measured up to now, or to
+// the end of the period if it is already over.
+//
+// Top, when positive, keeps only the Top projects and tags with the most time in the per-project and per-tag totals,
+// and combines the rest under [OtherKey].
 type ReportOptions struct {
-	Period    Period
-	ProjectID *string
-	TagIDs    []string
+	Period        Period
+	ProjectID     *string
+	TagIDs        []string
+	AnyTag        bool
+	Billable      *bool
+	GroupBy       GroupBy
+	FillZeroDays  bool
+	TagSeparator  string
+	From          *time.Time
+	To            *time.Time
+	Rounding      time.Duration
+	Rates         map[string]float64
+	Currency      string
+	Top           int
+	IncludeBilled bool
+	ExcludeActive bool
+	SplitMidnight bool
 }
 
-func GenerateReport(opts ReportOptions) (*model.ReportSummary, error) {
-	start, end := GetPeriodDateRange(opts.Period)
+// DateRange returns the half-open time range covered by the report.
+//
+// An explicit From/To range takes precedence over Period; otherwise the range comes from [GetPeriodDateRange].
+func (o ReportOptions) DateRange() (start, end time.Time) {
+	if o.From != nil && o.To != nil {
+		return *o.From, *o.To
+	}
+	return GetPeriodDateRange(o.Period)
+}
+
+// Label describes the report's range for display: the period name, or "2024-01-01 to 2024-01-15" for an explicit
+// range, where the end date is inclusive. A range starting during the day, such as one selected with --since, shows
+// its start time as well.
+func (o ReportOptions) Label() string {
+	if o.From != nil && o.To != nil {
+		from := o.From.Format(DayKeyFormat)
+		if !o.From.Equal(time.Date(o.From.Year(), o.From.Month(), o.From.Day(), 0, 0, 0, 0, o.From.Location())) {
+			from = o.From.Format("2006-01-02 15:04")
+		}
+		return from + " to " + o.To.AddDate(0, 0, -1).Format(DayKeyFormat)
+	}
+	return string(o.Period)
+}
 
-	listOpts := db.ListEntriesOptions{
+// EntryFilter returns the [db.ListEntriesOptions] that [GenerateReport] uses to select entries for opts.
+func EntryFilter(opts ReportOptions) db.ListEntriesOptions {
+	start, end := opts.DateRange()
+	filter := db.ListEntriesOptions{
 		From:      &start,
 		To:        &end,
 		ProjectID: opts.ProjectID,
 		TagIDs:    opts.TagIDs,
+		AnyTag:    opts.AnyTag,
+		Billable:  opts.Billable,
+	}
+	if !opts.IncludeBilled {
+		billed := false
+		filter.Billed = &billed
 	}
+	if opts.ExcludeActive {
+		stopped := model.StatusStopped
+		filter.Status = &stopped
+	}
+	return filter
+}
+
+func GenerateReport(opts ReportOptions) (*model.ReportSummary, error) {
+	listOpts := EntryFilter(opts)
+	start, end := *listOpts.From, *listOpts.To
 
 	entries, err := db.ListEntries(listOpts)
 	if err != nil {
@@ -105,21 +239,67 @@ func GenerateReport(opts ReportOptions) (*model.ReportSummary, error) {
 	}
 
 	summary := &model.ReportSummary{
-		Period:    string(opts.Period),
+		Period:    opts.Label(),
 		StartDate: start,
 		EndDate:   end,
 		ByProject: make(map[string]time.Duration),
 		ByTag:     make(map[string]time.Duration),
+		ByDay:     make(map[string]time.Duration),
 		Entries:   make([]model.ReportEntry, 0, len(entries)),
+
+		ByProjectAmount: make(map[string]float64),
+	}
+	if opts.GroupBy == GroupByTagSet {
+		summary.ByTagSet = make(map[string]time.Duration)
+	}
+	if opts.GroupBy == GroupByTagPrefix {
+		summary.ByTagPrefix = make(map[string]map[string]time.Duration)
+	}
+	if opts.GroupBy == GroupByPauseReason {
+		summary.ByPauseReason = make(map[string]time.Duration)
+	}
+	if opts.FillZeroDays {
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			summary.ByDay[day.Format(DayKeyFormat)] = 0
+		}
 	}
 
+	now := time.Now()
+	var reasons []string
 	for _, e := range entries {
-		duration := e.Duration()
+		raw := e.Duration()
+		duration, adjustments := adjustDuration(&e, raw, opts.Rounding, end, now)
+		for _, reason := range adjustments {
+			if !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+		if e.EndTime == nil && !end.Before(now) {
+			summary.ActiveEntries++
+		}
+		summary.RawTotal += raw
 		summary.TotalDuration += duration
+		if e.Billable {
+			summary.BillableDuration += duration
+		} else {
+			summary.NonBillableDuration += duration
+		}
+		if e.BilledAt != nil {
+			summary.BilledDuration += duration
+		} else {
+			summary.UnbilledDuration += duration
+		}
+		if e.Estimate != nil {
+			summary.EstimatedDuration += *e.Estimate
+			summary.EstimatedActual += duration
+		}
 
 		// Aggregate by project
 		if e.Project != nil {
 			summary.ByProject[e.Project.Name] += duration
+			if rate, ok := opts.Rates[e.Project.Name]; ok && e.Billable {
+				summary.ByProjectAmount[e.Project.Name] += duration.Hours() * rate
+			}
 		}
 
 		// Aggregate by tag
@@ -127,12 +307,67 @@ func GenerateReport(opts ReportOptions) (*model.ReportSummary, error) {
 			summary.ByTag[t.Name] += duration
 		}
 
+		// Aggregate by start day, or by each day the entry spans within the report range
+		if opts.SplitMidnight {
+			cutoff := now
+			if e.EndTime != nil {
+				cutoff = *e.EndTime
+			}
+			if end.Before(cutoff) {
+				cutoff = end
+			}
+			days := SplitByDay(&e, cutoff)
+			// Rounding and time past the end of the range are not split; they are added to the start day, as
+			// without splitting, so the days stay within the range and still add up to the total
+			var split time.Duration
+			for _, d := range days {
+				split += d
+			}
+			days[e.StartTime.Format(DayKeyFormat)] += duration - split
+			for day, d := range days {
+				summary.ByDay[day] += d
+			}
+		} else {
+			summary.ByDay[e.StartTime.Format(DayKeyFormat)] += duration
+		}
+
 		// Build tag names
 		tagNames := make([]string, len(e.Tags))
 		for i, t := range e.Tags {
 			tagNames[i] = t.Name
 		}
 
+		// Aggregate by tag combination
+		if summary.ByTagSet != nil {
+			summary.ByTagSet[TagSetKey(tagNames)] += duration
+		}
+
+		// Aggregate by tag prefix
+		if summary.ByTagPrefix != nil {
+			for _, name := range tagNames {
+				prefix, value := SplitTag(name, opts.TagSeparator)
+				if summary.ByTagPrefix[prefix] == nil {
+					summary.ByTagPrefix[prefix] = make(map[string]time.Duration)
+				}
+				summary.ByTagPrefix[prefix][value] += duration
+			}
+		}
+
+		// Aggregate pause time by reason
+		if summary.ByPauseReason != nil {
+			cutoff := now
+			if e.EndTime != nil {
+				cutoff = *e.EndTime
+			} else if end.Before(now) {
+				cutoff = end
+			}
+			for _, p := range e.Pauses {
+				if paused := pauseDurationUntil(p, cutoff); paused > 0 {
+					summary.ByPauseReason[p.Reason] += paused
+				}
+			}
+		}
+
 		projectName := ""
 		if e.Project != nil {
 			projectName = e.Project.Name
@@ -146,5 +381,235 @@ func GenerateReport(opts ReportOptions) (*model.ReportSummary, error) {
 		})
 	}
 
+	if opts.Top > 0 {
+		summary.ByProject = collapseTop(summary.ByProject, summary.ByProjectAmount, opts.Top)
+		summary.ByTag = collapseTop(summary.ByTag, nil, opts.Top)
+	}
+
+	switch opts.GroupBy {
+	case GroupByDay:
+		summary.Groups = summary.ByDay
+	case GroupByWeek:
+		weekStart, _ := config.WeekStart()
+		summary.Groups = make(map[string]time.Duration)
+		for day, duration := range summary.ByDay {
+			t, err := time.ParseInLocation(DayKeyFormat, day, db.Location)
+			if err != nil {
+				continue
+			}
+			summary.Groups[WeekKey(t, weekStart)] += duration
+		}
+	case GroupByProject:
+		summary.Groups = summary.ByProject
+	case GroupByTag:
+		summary.Groups = summary.ByTag
+	}
+	if summary.Groups != nil {
+		summary.GroupBy = string(opts.GroupBy)
+	}
+
+	for _, amount := range summary.ByProjectAmount {
+		summary.TotalAmount += amount
+	}
+	if len(summary.ByProjectAmount) == 0 {
+		summary.ByProjectAmount = nil
+	} else {
+		summary.Currency = opts.Currency
+	}
+
+	if summary.ActiveEntries > 0 {
+		summary.AsOf = &now
+	}
+
+	summary.AdjustedTotal = summary.TotalDuration
+	summary.AdjustmentReason = strings.Join(reasons, "; ")
+
 	return summary, nil
 }
+
+// WeekKey returns the [DayKeyFormat] date of the first day of the week containing t, for weeks starting on weekStart.
+func WeekKey(t time.Time, weekStart time.Weekday) string {
+	daysIntoWeek := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return t.AddDate(0, 0, -daysIntoWeek).Format(DayKeyFormat)
+}
+
+// adjustDuration applies report adjustments to an entry's raw duration.
+//
+// This is the single place where report-level clamping and rounding happen, so that [model.ReportSummary] can
+// reconcile the raw and adjusted totals. The adjustments are applied in order:
+//
+//   - An entry that is still open is clamped to periodEnd when the period has already ended, so a timer left running
+//     does not inflate a past report.
+//   - The duration is rounded up to a multiple of rounding, if rounding is positive.
+//
+// Returns the adjusted duration and a short reason for each adjustment that changed it.
+func adjustDuration(e *model.Entry, raw time.Duration, rounding time.Duration, periodEnd, now time.Time) (time.Duration, []string) {
+	duration := raw
+	var reasons []string
+
+	if e.EndTime == nil && periodEnd.Before(now) {
+		if clamped := durationUntil(e, periodEnd); clamped != duration {
+			duration = clamped
+			reasons = append(reasons, "open entries clamped to period end")
+		}
+	}
+
+	if rounded := RoundUp(duration, rounding); rounded != duration {
+		duration = rounded
+		reasons = append(reasons, "rounded up to "+formatIncrement(rounding)+" increments")
+	}
+
+	return duration, reasons
+}
+
+// OtherKey is the name under which [ReportOptions.Top] combines the projects and tags that did not make the cut. The
+// parentheses keep it from clashing with a real name, which cannot be looked up with them.
+const OtherKey = "(other)"
+
+// SortByDuration returns the keys of a per-project or per-tag breakdown, the longest first and ties in name order.
+// [OtherKey] always comes last, so the combined remainder reads as a footer.
+func SortByDuration(totals map[string]time.Duration) []string {
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if (a == OtherKey) != (b == OtherKey) {
+			return b == OtherKey
+		}
+		if totals[a] != totals[b] {
+			return totals[a] > totals[b]
+		}
+		return a < b
+	})
+	return keys
+}
+
+// collapseTop returns the n longest entries of totals, as ordered by [SortByDuration], with the rest summed under
+// [OtherKey]. Amounts of the collapsed keys, if amounts is not nil, are moved to [OtherKey] in place.
+//
+// totals is returned unchanged if it has no more than n keys.
+func collapseTop(totals map[string]time.Duration, amounts map[string]float64, n int) map[string]time.Duration {
+	if len(totals) <= n {
+		return totals
+	}
+
+	kept := make(map[string]time.Duration, n+1)
+	for i, key := range SortByDuration(totals) {
+		if i < n {
+			kept[key] = totals[key]
+			continue
+		}
+		kept[OtherKey] += totals[key]
+		if amount, ok := amounts[key]; ok {
+			amounts[OtherKey] += amount
+			delete(amounts, key)
+		}
+	}
+	return kept
+}
+
+// RoundUp rounds d up to the next multiple of increment. Exact multiples, non-positive durations, and a non-positive
+// increment leave d unchanged.
+func RoundUp(d, increment time.Duration) time.Duration {
+	if increment <= 0 || d <= 0 || d%increment == 0 {
+		return d
+	}
+	return d + increment - d%increment
+}
+
+// formatIncrement formats a rounding increment compactly, e.g. "15m" rather than "15m0s".
+func formatIncrement(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// durationUntil returns the worked duration of e as if it had stopped at cutoff.
+//
+// Pauses that are still open or extend past cutoff are closed at cutoff, and pauses starting after it are ignored.
+func durationUntil(e *model.Entry, cutoff time.Time) time.Duration {
+	if !cutoff.After(e.StartTime) {
+		return 0
+	}
+
+	clipped := *e
+	clipped.EndTime = &cutoff
+	clipped.Status = model.StatusStopped
+	clipped.Pauses = nil
+	for _, p := range e.Pauses {
+		if !p.PauseTime.Before(cutoff) {
+			continue
+		}
+		if p.ResumeTime == nil || p.ResumeTime.After(cutoff) {
+			p.ResumeTime = &cutoff
+		}
+		clipped.Pauses = append(clipped.Pauses, p)
+	}
+	return clipped.Duration()
+}
+
+// SplitByDay returns the worked duration of e on each local calendar day between its start and end, keyed in
+// [DayKeyFormat]. An entry still open is measured up to end as well, and pauses are split at midnight along with the
+// entry, so each day only loses the pause time that fell on it.
+//
+// The durations add up to the entry's duration up to end. Days without worked time are still present, with zero.
+func SplitByDay(e *model.Entry, end time.Time) map[string]time.Duration {
+	days := make(map[string]time.Duration)
+	start := e.StartTime
+	if !end.After(start) {
+		days[start.Format(DayKeyFormat)] = 0
+		return days
+	}
+
+	var before time.Duration
+	for day := start; day.Before(end); {
+		next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location())
+		if next.After(end) {
+			next = end
+		}
+		until := durationUntil(e, next)
+		days[day.Format(DayKeyFormat)] += until - before
+		before = until
+		day = next
+	}
+	return days
+}
+
+// pauseDurationUntil returns the duration of p, closing it at cutoff if it is still open or extends past it.
+func pauseDurationUntil(p model.Pause, cutoff time.Time) time.Duration {
+	if !p.PauseTime.Before(cutoff) {
+		return 0
+	}
+	if p.ResumeTime == nil || p.ResumeTime.After(cutoff) {
+		return cutoff.Sub(p.PauseTime)
+	}
+	return p.Duration()
+}
+
+// TagSetKey returns the [model.ReportSummary.ByTagSet] key for the given tag names: the names sorted and joined with
+// commas. The input slice is not modified.
+func TagSetKey(tagNames []string) string {
+	sorted := append([]string(nil), tagNames...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// SplitTag splits a namespaced tag like "client:acme" at the first occurrence of sep into its prefix and value.
+//
+// A tag without sep, or an empty sep, yields the whole name as the prefix and an empty value.
+func SplitTag(name, sep string) (prefix, value string) {
+	if sep == "" {
+		return name, ""
+	}
+	if i := strings.Index(name, sep); i >= 0 {
+		return name[:i], name[i+len(sep):]
+	}
+	return name, ""
+}
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// loadLocation returns the named zone, skipping the test if it is not available.
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+// useLocation sets [db.Location] to the named zone for the rest of the test.
+func useLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc := loadLocation(t, name)
+	original := db.Location
+	db.Location = loc
+	t.Cleanup(func() { db.Location = original })
+	return loc
+}
+
+func TestPeriodDateRangeDST(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+
+	// Clocks sprang forward on 2024-03-10 and fell back on 2024-11-03, both Sundays
+	tests := []struct {
+		name      string
+		period    Period
+		now       time.Time
+		weekStart time.Weekday
+		start     time.Time
+		end       time.Time
+		length    time.Duration
+	}{
+		{
+			name:   "spring-forward day is 23 hours",
+			period: PeriodToday,
+			now:    time.Date(2024, 3, 10, 12, 0, 0, 0, loc),
+			start:  date(2024, 3, 10),
+			end:    date(2024, 3, 11),
+			length: 23 * time.Hour,
+		},
+		{
+			name:   "day after spring-forward",
+			period: PeriodYesterday,
+			now:    time.Date(2024, 3, 11, 9, 0, 0, 0, loc),
+			start:  date(2024, 3, 10),
+			end:    date(2024, 3, 11),
+			length: 23 * time.Hour,
+		},
+		{
+			name:      "week ending on spring-forward",
+			period:    PeriodWeek,
+			now:       time.Date(2024, 3, 10, 12, 0, 0, 0, loc),
+			weekStart: time.Monday,
+			start:     date(2024, 3, 4),
+			end:       date(2024, 3, 11),
+			length:    7*24*time.Hour - time.Hour,
+		},
+		{
+			name:      "last week across spring-forward",
+			period:    PeriodLastWeek,
+			now:       time.Date(2024, 3, 13, 12, 0, 0, 0, loc),
+			weekStart: time.Monday,
+			start:     date(2024, 3, 4),
+			end:       date(2024, 3, 11),
+			length:    7*24*time.Hour - time.Hour,
+		},
+		{
+			name:   "fall-back day is 25 hours",
+			period: PeriodToday,
+			now:    time.Date(2024, 11, 3, 12, 0, 0, 0, loc),
+			start:  date(2024, 11, 3),
+			end:    date(2024, 11, 4),
+			length: 25 * time.Hour,
+		},
+		{
+			name:   "fall-back day from the repeated hour",
+			period: PeriodToday,
+			now:    time.Date(2024, 11, 3, 1, 30, 0, 0, loc).Add(time.Hour),
+			start:  date(2024, 11, 3),
+			end:    date(2024, 11, 4),
+			length: 25 * time.Hour,
+		},
+		{
+			name:      "week starting on fall-back",
+			period:    PeriodWeek,
+			now:       time.Date(2024, 11, 5, 12, 0, 0, 0, loc),
+			weekStart: time.Sunday,
+			start:     date(2024, 11, 3),
+			end:       date(2024, 11, 6),
+			length:    3*24*time.Hour + time.Hour,
+		},
+		{
+			name:      "last week across fall-back",
+			period:    PeriodLastWeek,
+			now:       time.Date(2024, 11, 6, 12, 0, 0, 0, loc),
+			weekStart: time.Monday,
+			start:     date(2024, 10, 28),
+			end:       date(2024, 11, 4),
+			length:    7*24*time.Hour + time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := PeriodDateRange(tt.period, tt.now, tt.weekStart)
+			if !start.Equal(tt.start) || !end.Equal(tt.end) {
+				t.Errorf("PeriodDateRange() = %v - %v, want %v - %v", start, end, tt.start, tt.end)
+			}
+			if got := end.Sub(start); got != tt.length {
+				t.Errorf("range length = %v, want %v", got, tt.length)
+			}
+			for _, bound := range []time.Time{start, end} {
+				if h, m, _ := bound.In(loc).Clock(); h != 0 || m != 0 {
+					t.Errorf("bound %v is not local midnight", bound)
+				}
+			}
+		})
+	}
+}
+
+func TestAdjustDuration(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-2 * time.Hour)
+	end := now.Add(-time.Hour)
+	stopped := &model.Entry{StartTime: start, EndTime: &end, Status: model.StatusStopped}
+
+	tests := []struct {
+		name     string
+		raw      time.Duration
+		rounding time.Duration
+		want     time.Duration
+		reasons  int
+	}{
+		{"1m rounds up to 15m", time.Minute, 15 * time.Minute, 15 * time.Minute, 1},
+		{"exact multiple of 15m is unchanged", 30 * time.Minute, 15 * time.Minute, 30 * time.Minute, 0},
+		{"exact hour is unchanged by 5m", time.Hour, 5 * time.Minute, time.Hour, 0},
+		{"just over a multiple rounds up", 15*time.Minute + time.Second, 15 * time.Minute, 30 * time.Minute, 1},
+		{"no rounding keeps seconds", 61 * time.Second, 0, 61 * time.Second, 0},
+		{"zero stays zero", 0, 15 * time.Minute, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reasons := adjustDuration(stopped, tt.raw, tt.rounding, now, now)
+			if got != tt.want {
+				t.Errorf("adjustDuration() = %v, want %v", got, tt.want)
+			}
+			if len(reasons) != tt.reasons {
+				t.Errorf("adjustDuration() reasons = %v, want %d", reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestPeriodDateRangeWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 1, 14, 15, 30, 0, 0, time.UTC)
+	date := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		period    Period
+		weekStart time.Weekday
+		start     time.Time
+		end       time.Time
+	}{
+		{"week starting monday", PeriodWeek, time.Monday, date(8), date(15)},
+		{"last week starting monday", PeriodLastWeek, time.Monday, date(1), date(8)},
+		{"week starting sunday", PeriodWeek, time.Sunday, date(14), date(15)},
+		{"last week starting sunday", PeriodLastWeek, time.Sunday, date(7), date(14)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := PeriodDateRange(tt.period, sunday, tt.weekStart)
+			if !start.Equal(tt.start) || !end.Equal(tt.end) {
+				t.Errorf("PeriodDateRange(%s, %s) = %v - %v, want %v - %v",
+					tt.period, tt.weekStart, start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestSortByDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals map[string]time.Duration
+		want   []string
+	}{
+		{
+			name:   "three projects, longest first",
+			totals: map[string]time.Duration{"beta": time.Hour, "alpha": 30 * time.Minute, "gamma": 2 * time.Hour},
+			want:   []string{"gamma", "beta", "alpha"},
+		},
+		{
+			name:   "ties in name order",
+			totals: map[string]time.Duration{"gamma": time.Hour, "alpha": time.Hour, "beta": 2 * time.Hour},
+			want:   []string{"beta", "alpha", "gamma"},
+		},
+		{
+			name:   "other comes last",
+			totals: map[string]time.Duration{OtherKey: 5 * time.Hour, "alpha": time.Hour, "beta": 2 * time.Hour},
+			want:   []string{"beta", "alpha", OtherKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map iteration order is random, so repeat to catch order that depends on it
+			for range 20 {
+				if got := SortByDuration(tt.totals); !slices.Equal(got, tt.want) {
+					t.Fatalf("SortByDuration() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// openTestDB initializes [db.DB] in a temporary data directory that is removed when the test ends.
+func openTestDB(t *testing.T) {
+	t.Helper()
+	db.DataDirOverride = t.TempDir()
+	if err := db.Init(); err != nil {
+		t.Fatalf("db.Init() error = %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		db.DB = nil
+		db.DataDirOverride = ""
+	})
+}
+
+// addEntry creates a stopped entry on project with the given tags, lasting d from start.
+func addEntry(t *testing.T, project, title string, tags []string, start time.Time, d time.Duration) {
+	t.Helper()
+	p, err := db.GetOrCreateProject(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tagIDs []string
+	for _, name := range tags {
+		tag, err := db.GetOrCreateTag(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	if _, err := db.CreateCompletedEntry(p.ID, title, tagIDs, start, start.Add(d)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateReportFillZeroDays(t *testing.T) {
+	useLocation(t, "UTC")
+	openTestDB(t)
+
+	// A week from Monday 2024-01-15 with nothing tracked on Thursday, the middle day
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	for _, day := range []int{0, 1, 2, 4, 5, 6} {
+		addEntry(t, "work", "Task", nil, from.AddDate(0, 0, day).Add(9*time.Hour), time.Hour)
+	}
+
+	tests := []struct {
+		name string
+		fill bool
+		days int
+	}{
+		{"sparse by default", false, 6},
+		{"filled", true, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := GenerateReport(ReportOptions{From: &from, To: &to, FillZeroDays: tt.fill})
+			if err != nil {
+				t.Fatalf("GenerateReport() error = %v", err)
+			}
+			if len(summary.ByDay) != tt.days {
+				t.Errorf("ByDay has %d days, want %d: %v", len(summary.ByDay), tt.days, summary.ByDay)
+			}
+			thursday, ok := summary.ByDay["2024-01-18"]
+			if ok != tt.fill || thursday != 0 {
+				t.Errorf("ByDay[2024-01-18] = %v, %v; want 0, %v", thursday, ok, tt.fill)
+			}
+			if got := summary.ByDay["2024-01-17"]; got != time.Hour {
+				t.Errorf("ByDay[2024-01-17] = %v, want 1h", got)
+			}
+			if _, ok := summary.ByDay["2024-01-22"]; ok {
+				t.Error("ByDay includes 2024-01-22, past the end of the range")
+			}
+		})
+	}
+}
+
+func TestGenerateReportProjectAndTagFilter(t *testing.T) {
+	useLocation(t, "UTC")
+	openTestDB(t)
+
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	at := func(hour int) time.Time { return from.Add(time.Duration(hour) * time.Hour) }
+	addEntry(t, "work", "Match", []string{"meeting", "client"}, at(8), time.Hour)
+	addEntry(t, "work", "Match too", []string{"meeting"}, at(10), 30*time.Minute)
+	addEntry(t, "work", "Wrong tag", []string{"client"}, at(11), 2*time.Hour)
+	addEntry(t, "home", "Wrong project", []string{"meeting"}, at(14), 3*time.Hour)
+	addEntry(t, "work", "Untagged", nil, at(18), 4*time.Hour)
+
+	project, err := db.GetProjectByName("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := db.GetTagByName("meeting")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := GenerateReport(ReportOptions{From: &from, To: &to, ProjectID: &project.ID, TagIDs: []string{tag.ID}})
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	var titles []string
+	for _, e := range summary.Entries {
+		titles = append(titles, e.Title)
+	}
+	slices.Sort(titles)
+	if want := []string{"Match", "Match too"}; !slices.Equal(titles, want) {
+		t.Errorf("report entries = %v, want %v", titles, want)
+	}
+	if summary.TotalDuration != 90*time.Minute {
+		t.Errorf("TotalDuration = %v, want 1h30m", summary.TotalDuration)
+	}
+	if want := map[string]time.Duration{"work": 90 * time.Minute}; !maps.Equal(summary.ByProject, want) {
+		t.Errorf("ByProject = %v, want %v", summary.ByProject, want)
+	}
+	want := map[string]time.Duration{"meeting": 90 * time.Minute, "client": time.Hour}
+	if !maps.Equal(summary.ByTag, want) {
+		t.Errorf("ByTag = %v, want %v", summary.ByTag, want)
+	}
+}
+
+func TestGenerateReportSplitMidnightStaysInRange(t *testing.T) {
+	useLocation(t, "UTC")
+	openTestDB(t)
+
+	// Two hours from 23:00 on the 15th to 01:00 on the 16th
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	addEntry(t, "work", "Late", nil, from.Add(23*time.Hour), 2*time.Hour)
+
+	tests := []struct {
+		name string
+		days int
+		want map[string]time.Duration
+	}{
+		{"range ends at midnight", 1, map[string]time.Duration{"2024-01-15": 2 * time.Hour}},
+		{"range covers both days", 2, map[string]time.Duration{"2024-01-15": time.Hour, "2024-01-16": time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := from.AddDate(0, 0, tt.days)
+			summary, err := GenerateReport(ReportOptions{From: &from, To: &to, SplitMidnight: true})
+			if err != nil {
+				t.Fatalf("GenerateReport() error = %v", err)
+			}
+			if !maps.Equal(summary.ByDay, tt.want) {
+				t.Errorf("ByDay = %v, want %v", summary.ByDay, tt.want)
+			}
+			if summary.TotalDuration != 2*time.Hour {
+				t.Errorf("TotalDuration = %v, want 2h", summary.TotalDuration)
+			}
+		})
+	}
+}
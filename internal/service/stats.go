@@ -0,0 +1,112 @@
+package service
+
+import (
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// WeekdayTotal is the tracked time on one day of the week.
+type WeekdayTotal struct {
+	Weekday  string        `json:"weekday"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Stats summarizes tracking habits over a set of entries.
+//
+// Sessions counts every entry, but AverageSession and LongestSession only consider stopped entries, since a running
+// or paused entry has no final duration yet. OpenSessions counts the entries that were left out. LongestEntryID is
+// empty when there are no stopped entries.
+//
+// MostUsedProject and MostUsedTag are chosen by tracked time, and are empty when there are no entries or no tags.
+//
+// ByWeekday always lists all seven days, starting on Monday, attributing each entry to the day it started.
+type Stats struct {
+	TotalDuration   time.Duration  `json:"total_duration"`
+	Sessions        int            `json:"sessions"`
+	OpenSessions    int            `json:"open_sessions"`
+	AverageSession  time.Duration  `json:"average_session"`
+	LongestSession  time.Duration  `json:"longest_session"`
+	LongestEntryID  string         `json:"longest_entry_id,omitempty"`
+	MostUsedProject string         `json:"most_used_project,omitempty"`
+	MostUsedTag     string         `json:"most_used_tag,omitempty"`
+	ByWeekday       []WeekdayTotal `json:"by_weekday"`
+}
+
+// ComputeStats loads the entries in the given period and summarizes them. An empty period covers all entries.
+//
+// Returns the statistics, or an error if the entries cannot be loaded.
+func ComputeStats(period Period) (*Stats, error) {
+	var opts db.ListEntriesOptions
+	if period != "" {
+		start, end := GetPeriodDateRange(period)
+		opts.From, opts.To = &start, &end
+	}
+
+	entries, err := db.ListEntries(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeStats(entries), nil
+}
+
+// computeStats summarizes the given entries. See [ComputeStats].
+func computeStats(entries []model.Entry) *Stats {
+	stats := &Stats{Sessions: len(entries)}
+
+	byProject := make(map[string]time.Duration)
+	byTag := make(map[string]time.Duration)
+	var byWeekday [7]time.Duration
+	var closedTotal time.Duration
+	closed := 0
+
+	for _, e := range entries {
+		duration := e.Duration()
+		stats.TotalDuration += duration
+		byWeekday[e.StartTime.Weekday()] += duration
+
+		if e.Project != nil {
+			byProject[e.Project.Name] += duration
+		}
+		for _, t := range e.Tags {
+			byTag[t.Name] += duration
+		}
+
+		if e.Status != model.StatusStopped {
+			stats.OpenSessions++
+			continue
+		}
+		closed++
+		closedTotal += duration
+		if stats.LongestEntryID == "" || duration > stats.LongestSession {
+			stats.LongestSession = duration
+			stats.LongestEntryID = e.ID
+		}
+	}
+
+	if closed > 0 {
+		stats.AverageSession = closedTotal / time.Duration(closed)
+	}
+	stats.MostUsedProject = largest(byProject)
+	stats.MostUsedTag = largest(byTag)
+
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((i + 1) % 7)
+		stats.ByWeekday = append(stats.ByWeekday, WeekdayTotal{Weekday: day.String(), Duration: byWeekday[day]})
+	}
+
+	return stats
+}
+
+// largest returns the key with the largest duration, breaking ties by name so the result is stable.
+func largest(totals map[string]time.Duration) string {
+	var best string
+	for name, d := range totals {
+		if best == "" || d > totals[best] || (d == totals[best] && name < best) {
+			best = name
+		}
+	}
+	return best
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thinktide/tally/internal/model"
+)
+
+// EventKind identifies what happened at a point in a [Timeline].
+type EventKind string
+
+const (
+	EventStart  EventKind = "start"
+	EventPause  EventKind = "pause"
+	EventResume EventKind = "resume"
+	EventStop   EventKind = "stop"
+)
+
+// Event is a single point in a [Timeline].
+//
+// Reason is only set for pause events. Duration is only set for stop events and holds the entry's worked duration,
+// excluding pauses.
+type Event struct {
+	Time     time.Time     `json:"time"`
+	Kind     EventKind     `json:"kind"`
+	EntryID  string        `json:"entry_id"`
+	Project  string        `json:"project"`
+	Title    string        `json:"title,omitempty"`
+	Reason   string        `json:"reason,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Timeline flattens entries and their pauses into a chronological list of events.
+//
+// Each entry contributes a start event, a pause and resume event per pause, and a stop event if it has ended. Events
+// at the same instant keep the order in which they occurred within their entry, except that an entry stopping is
+// listed before another entry starting.
+func Timeline(entries []model.Entry) []Event {
+	var events []Event
+	for _, e := range entries {
+		projectName := ""
+		if e.Project != nil {
+			projectName = e.Project.Name
+		}
+		base := Event{EntryID: e.ID, Project: projectName, Title: e.Title}
+
+		start := base
+		start.Time, start.Kind = e.StartTime, EventStart
+		events = append(events, start)
+
+		for _, p := range e.Pauses {
+			pause := base
+			pause.Time, pause.Kind, pause.Reason = p.PauseTime, EventPause, p.Reason
+			events = append(events, pause)
+
+			if p.ResumeTime != nil {
+				resume := base
+				resume.Time, resume.Kind = *p.ResumeTime, EventResume
+				events = append(events, resume)
+			}
+		}
+
+		if e.EndTime != nil {
+			stop := base
+			stop.Time, stop.Kind, stop.Duration = *e.EndTime, EventStop, e.Duration()
+			events = append(events, stop)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		// When one entry stops as another starts, show the stop first
+		return a.EntryID != b.EntryID && a.Kind == EventStop && b.Kind != EventStop
+	})
+	return events
+}
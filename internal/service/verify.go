@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thinktide/tally/internal/model"
+)
+
+// longSpan is the wall-clock span above which a zero worked duration is reported as suspicious.
+const longSpan = 5 * time.Minute
+
+// VerifyEntry checks the duration and pause math of a single entry.
+//
+// It reports a negative duration, a zero duration despite a long span, pauses that fall outside the entry's time
+// window, and pauses that overlap each other. Running and paused entries are checked against the present.
+//
+// Returns a human-readable description of each problem found, or nil if the entry is consistent.
+func VerifyEntry(e *model.Entry) []string {
+	var problems []string
+
+	start, end := entryWindow(e)
+	duration := e.Duration()
+	switch {
+	case duration < 0:
+		problems = append(problems, fmt.Sprintf("negative duration (%s)", duration.Round(time.Second)))
+	case duration == 0 && end.Sub(start) >= longSpan:
+		problems = append(problems, fmt.Sprintf("zero duration despite a span of %s", end.Sub(start).Round(time.Second)))
+	}
+
+	var prevResume *time.Time
+	for _, p := range e.Pauses {
+		pauseEnd := end
+		if p.ResumeTime != nil {
+			pauseEnd = *p.ResumeTime
+		}
+
+		if p.PauseTime.Before(start) || pauseEnd.After(end) {
+			problems = append(problems, fmt.Sprintf("pause %s (%s - %s) falls outside the entry",
+				p.ID, p.PauseTime.Format("2006-01-02 15:04:05"), pauseEnd.Format("2006-01-02 15:04:05")))
+		}
+		if pauseEnd.Before(p.PauseTime) {
+			problems = append(problems, fmt.Sprintf("pause %s ends before it starts", p.ID))
+		}
+		if prevResume != nil && p.PauseTime.Before(*prevResume) {
+			problems = append(problems, fmt.Sprintf("pause %s overlaps the previous pause", p.ID))
+		}
+
+		if prevResume == nil || pauseEnd.After(*prevResume) {
+			prevResume = &pauseEnd
+		}
+	}
+
+	return problems
+}
+
+// ClampPauses fits an entry's pauses inside its time window and removes overlaps between them.
+//
+// Pauses are expected in chronological order, as returned by the database. Each pause is clamped to the entry's
+// window and to start no earlier than the previous pause ended. A pause left open on a stopped entry is closed at
+// the entry's end time. Pauses that become empty are returned in removed rather than kept.
+//
+// Returns the adjusted pauses to keep and the pauses to delete. The entry itself is not modified.
+func ClampPauses(e *model.Entry) (kept, removed []model.Pause) {
+	start, end := entryWindow(e)
+	open := e.Status != model.StatusStopped
+
+	var prevResume time.Time
+	for _, p := range e.Pauses {
+		if p.PauseTime.Before(start) {
+			p.PauseTime = start
+		}
+		if !prevResume.IsZero() && p.PauseTime.Before(prevResume) {
+			p.PauseTime = prevResume
+		}
+
+		if p.ResumeTime != nil && p.ResumeTime.After(end) {
+			p.ResumeTime = &end
+		}
+		if p.ResumeTime == nil && !open {
+			p.ResumeTime = &end
+		}
+
+		pauseEnd := end
+		if p.ResumeTime != nil {
+			pauseEnd = *p.ResumeTime
+		}
+		if !pauseEnd.After(p.PauseTime) {
+			removed = append(removed, p)
+			continue
+		}
+
+		kept = append(kept, p)
+		prevResume = pauseEnd
+	}
+
+	return kept, removed
+}
+
+// entryWindow returns the wall-clock time range of an entry, using the present as the end of an active entry.
+func entryWindow(e *model.Entry) (start, end time.Time) {
+	end = time.Now()
+	if e.EndTime != nil {
+		end = *e.EndTime
+	}
+	return e.StartTime, end
+}
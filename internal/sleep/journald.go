@@ -0,0 +1,59 @@
+package sleep
+
+import (
+	"bufio"
+	"strings"
+	"time"
+)
+
+// journaldTimeFormats are the layouts of the timestamp printed by `journalctl -o short-iso`, which changed from a
+// numeric zone without a colon to RFC 3339 in systemd 249.
+var journaldTimeFormats = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339,
+}
+
+// parseJournaldLog extracts sleep periods from the output of `journalctl -o short-iso` for systemd-suspend and
+// related units.
+//
+// A period starts at an "Entering sleep state" message and ends at the next "System returned from sleep state"
+// message. Lines that are not log messages, such as "-- Boot ... --" separators, are skipped. A sleep that was never
+// followed by a resume, for example because the machine lost power, is ignored.
+func parseJournaldLog(output string) []SleepPeriod {
+	var periods []SleepPeriod
+	var sleepStart *time.Time
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		stamp, message, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+
+		var t time.Time
+		var err error
+		for _, layout := range journaldTimeFormats {
+			if t, err = time.Parse(layout, stamp); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case strings.Contains(message, "Entering sleep state"):
+			if sleepStart == nil {
+				sleepStart = &t
+			}
+		case strings.Contains(message, "returned from sleep state"):
+			if sleepStart != nil {
+				periods = append(periods, SleepPeriod{Start: sleepStart.Local(), End: t.Local(), Reason: "System suspend"})
+				sleepStart = nil
+			}
+		}
+	}
+
+	return periods
+}
@@ -0,0 +1,90 @@
+package sleep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJournaldLog(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []SleepPeriod
+	}{
+		{
+			name: "numeric zone without colon",
+			output: `-- Logs begin at Mon 2024-03-04 09:12:40 CET, end at Tue 2024-03-05 07:42:10 CET. --
+2024-03-04T22:15:01+0100 laptop systemd[1]: Starting System Suspend...
+2024-03-04T22:15:01+0100 laptop systemd-sleep[12345]: Entering sleep state 'suspend'...
+2024-03-05T07:42:10+0100 laptop systemd-sleep[12345]: System returned from sleep state.
+2024-03-05T07:42:10+0100 laptop systemd[1]: systemd-suspend.service: Deactivated successfully.
+2024-03-05T07:42:10+0100 laptop systemd[1]: Finished System Suspend.
+`,
+			want: []SleepPeriod{{
+				Start: time.Date(2024, 3, 4, 21, 15, 1, 0, time.UTC),
+				End:   time.Date(2024, 3, 5, 6, 42, 10, 0, time.UTC),
+			}},
+		},
+		{
+			name: "RFC 3339 zone across boots",
+			output: `2024-06-10T12:00:00+02:00 laptop systemd-sleep[801]: Entering sleep state 'suspend'...
+2024-06-10T12:30:00+02:00 laptop systemd-sleep[801]: System returned from sleep state.
+-- Boot 3f2a6c0e9b1d4a7e8c5f2b1a0d9e8c7b --
+2024-06-10T18:00:00+02:00 laptop systemd-sleep[2044]: Entering sleep state 'suspend'...
+2024-06-10T18:05:00+02:00 laptop systemd-sleep[2044]: System returned from sleep state.
+`,
+			want: []SleepPeriod{
+				{Start: time.Date(2024, 6, 10, 10, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 10, 10, 30, 0, 0, time.UTC)},
+				{Start: time.Date(2024, 6, 10, 16, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 10, 16, 5, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "malformed lines are skipped",
+			output: `garbage
+2024-06-10 12:00:00 laptop systemd-sleep[801]: Entering sleep state 'suspend'...
+2024-06-10T12:00:00+02:00
+2024-06-10T12:10:00+02:00 laptop systemd-sleep[801]: Entering sleep state 'suspend'...
+not-a-time laptop systemd-sleep[801]: System returned from sleep state.
+2024-06-10T12:40:00+02:00 laptop systemd-sleep[801]: System returned from sleep state.
+`,
+			want: []SleepPeriod{
+				{Start: time.Date(2024, 6, 10, 10, 10, 0, 0, time.UTC), End: time.Date(2024, 6, 10, 10, 40, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "sleep without resume is ignored",
+			output: `2024-06-10T12:00:00+02:00 laptop systemd-sleep[801]: System returned from sleep state.
+2024-06-10T23:00:00+02:00 laptop systemd-sleep[801]: Entering sleep state 'suspend'...
+`,
+			want: nil,
+		},
+		{
+			name:   "no entries",
+			output: "-- No entries --\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseJournaldLog(tt.output)
+			assertPeriods(t, got, tt.want, "System suspend")
+		})
+	}
+}
+
+// assertPeriods fails t unless got has the start and end times of want, in order, and every period has reason.
+func assertPeriods(t *testing.T, got, want []SleepPeriod, reason string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d periods %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("period %d = %s - %s, want %s - %s", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+		if got[i].Reason != reason {
+			t.Errorf("period %d reason = %q, want %q", i, got[i].Reason, reason)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package sleep
+
+import (
+	"bufio"
+	"strings"
+	"time"
+)
+
+// pmsetTimeFormat is the layout of the timestamp that starts each line of `pmset -g log`.
+const pmsetTimeFormat = "2006-01-02 15:04:05 -0700"
+
+// parsePmsetLog extracts sleep periods from the output of `pmset -g log`.
+//
+// A period starts at a "Sleep" event and ends at the next full "Wake" event. "DarkWake" events, where the system
+// briefly wakes for background work without turning on the display, do not end a period. A trailing sleep without a
+// wake is ignored, since the system is evidently awake now.
+func parsePmsetLog(output string) []SleepPeriod {
+	var periods []SleepPeriod
+	var sleepStart *time.Time
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < len(pmsetTimeFormat) {
+			continue
+		}
+		t, err := time.Parse(pmsetTimeFormat, line[:len(pmsetTimeFormat)])
+		if err != nil {
+			continue
+		}
+
+		fields := strings.Fields(line[len(pmsetTimeFormat):])
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "Sleep":
+			if sleepStart == nil {
+				sleepStart = &t
+			}
+		case "Wake":
+			if sleepStart != nil {
+				periods = append(periods, SleepPeriod{Start: sleepStart.Local(), End: t.Local(), Reason: "System sleep"})
+				sleepStart = nil
+			}
+		}
+	}
+
+	return periods
+}
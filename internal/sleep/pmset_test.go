@@ -0,0 +1,69 @@
+package sleep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePmsetLog(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []SleepPeriod
+	}{
+		{
+			name: "dark wakes do not end a sleep",
+			output: `Time stamp                Domain              	Message                                                                         	Duration  	Delay
+==========                ======              	=======                                                                         	========  	=====
+2024-03-04 22:15:03 +0100 Sleep               	Entering Sleep state due to 'Clamshell Sleep':TCPKeepAlive=active Using Batt (Charge:87%)	          
+2024-03-05 03:12:44 +0100 DarkWake            	DarkWake from Deep Idle [CDNP] : due to NUB.SPMI0.SW3 SMC.OutboxNotEmpty/ Using BATT (Charge:85%) 2 secs
+2024-03-05 03:12:50 +0100 Sleep               	Entering Sleep state due to 'Maintenance Sleep':TCPKeepAlive=active Using Batt (Charge:85%)
+2024-03-05 07:42:11 +0100 Wake                	Wake from Deep Idle [CDNPB] : due to UserActivity Assertion/ Using BATT (Charge:84%)
+2024-03-05 07:42:12 +0100 Assertions          	PID 412(loginwindow) Created UserIsActive "com.apple.loginwindow" 00:00:00
+`,
+			want: []SleepPeriod{{
+				Start: time.Date(2024, 3, 4, 21, 15, 3, 0, time.UTC),
+				End:   time.Date(2024, 3, 5, 6, 42, 11, 0, time.UTC),
+			}},
+		},
+		{
+			name: "several sleeps",
+			output: `2024-06-10 12:00:00 -0700 Sleep               	Entering Sleep state due to 'Idle Sleep'
+2024-06-10 12:45:00 -0700 Wake                	Wake from Normal Sleep [CDNVA] : due to EC.LidOpen/Lid Open
+2024-06-10 18:00:00 -0700 Sleep               	Entering Sleep state due to 'Software Sleep pid=123'
+2024-06-10 18:01:30 -0700 Wake                	Wake from Normal Sleep [CDNVA] : due to EC.PowerButton
+`,
+			want: []SleepPeriod{
+				{Start: time.Date(2024, 6, 10, 19, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 10, 19, 45, 0, 0, time.UTC)},
+				{Start: time.Date(2024, 6, 11, 1, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 11, 1, 1, 30, 0, time.UTC)},
+			},
+		},
+		{
+			name: "malformed lines are skipped",
+			output: `Total Sleep/Wakes since boot:12
+2024-06-10 12:00 -0700 Sleep	Entering Sleep state
+2024-06-10 12:00:00 -0700 Sleep               	Entering Sleep state due to 'Idle Sleep'
+2024-13-10 12:30:00 -0700 Wake                	Wake from Normal Sleep
+2024-06-10 12:40:00 -0700
+2024-06-10 12:50:00 -0700 Wake                	Wake from Normal Sleep [CDNVA] : due to EC.LidOpen/Lid Open
+`,
+			want: []SleepPeriod{
+				{Start: time.Date(2024, 6, 10, 19, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 10, 19, 50, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "trailing sleep without wake is ignored",
+			output: `2024-06-10 12:00:00 -0700 Wake                	Wake from Normal Sleep
+2024-06-10 23:00:00 -0700 Sleep               	Entering Sleep state due to 'Idle Sleep'
+`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePmsetLog(tt.output)
+			assertPeriods(t, got, tt.want, "System sleep")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package sleep detects periods when the computer was asleep and records them as pauses on the running timer.
+//
+// Sleep events are read from the operating system's power log: `pmset` on macOS and the systemd journal on Linux.
+// Other platforms report [ErrUnsupported].
+package sleep
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thinktide/tally/internal/db"
+	"github.com/thinktide/tally/internal/model"
+)
+
+// MinDuration is the shortest sleep that is recorded. Shorter periods, such as brief display sleeps, are ignored.
+const MinDuration = time.Minute
+
+// ErrUnsupported is returned on platforms without a known source of sleep events.
+var ErrUnsupported = errors.New("sleep detection is not supported on this platform")
+
+// SleepPeriod is a span of time during which the system was asleep.
+//
+// Reason describes the kind of sleep, such as "System sleep" or "System suspend", and is used as the reason of the
+// pause recorded for it.
+type SleepPeriod struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// Duration returns the length of the sleep period.
+func (p SleepPeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// GetSleepPeriodsSince returns the completed sleep periods that ended after since, in chronological order.
+//
+// Periods shorter than [MinDuration] are dropped.
+//
+// Returns [ErrUnsupported] on platforms without sleep detection, or an error if the power log cannot be read.
+func GetSleepPeriodsSince(since time.Time) ([]SleepPeriod, error) {
+	periods, err := getSleepPeriodsSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+
+	var result []SleepPeriod
+	for _, p := range periods {
+		if p.End.After(since) && p.Duration() >= MinDuration {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// CheckAndHandleSleep records sleep periods that happened while the current timer was running as pauses.
+//
+// Only a running timer is considered; sleep while paused is already excluded from its duration. Detection starts at
+// the later of the entry's start and the end of its most recent pause, so periods recorded by an earlier check are
+// not recorded twice. A period that began before that point is trimmed to it.
+//
+// Nothing is printed, so callers can decide how (and whether) to report the result.
+//
+// Returns the periods that were recorded as pauses, or an error if the power log or database cannot be read.
+func CheckAndHandleSleep() ([]SleepPeriod, error) {
+	entry, err := db.GetRunningEntry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running entry: %w", err)
+	}
+	if entry == nil || entry.Status != model.StatusRunning {
+		return nil, nil
+	}
+
+	since := entry.StartTime
+	for _, p := range entry.Pauses {
+		if p.ResumeTime != nil && p.ResumeTime.After(since) {
+			since = *p.ResumeTime
+		}
+	}
+
+	periods, err := GetSleepPeriodsSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded []SleepPeriod
+	for _, p := range periods {
+		if p.Start.Before(since) {
+			p.Start = since
+		}
+		if p.Duration() < MinDuration {
+			continue
+		}
+
+		end := p.End
+		if _, err := db.CreatePause(entry.ID, p.Start, &end, p.Reason); err != nil {
+			return recorded, fmt.Errorf("failed to create pause: %w", err)
+		}
+		recorded = append(recorded, p)
+		since = p.End
+	}
+
+	return recorded, nil
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package sleep
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// getSleepPeriodsSince reads the macOS power management log.
+//
+// `pmset -g log` has no time filter, so the whole log is parsed and [GetSleepPeriodsSince] drops older periods.
+func getSleepPeriodsSince(since time.Time) ([]SleepPeriod, error) {
+	out, err := exec.Command("pmset", "-g", "log").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get power log: %w", err)
+	}
+	return parsePmsetLog(string(out)), nil
+}
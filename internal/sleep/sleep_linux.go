@@ -0,0 +1,33 @@
+//go:build linux
+
+package sleep
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// getSleepPeriodsSince reads suspend and resume events from the systemd journal.
+//
+// The search starts an hour before since so that a sleep which began shortly before it is still paired with its
+// resume. Requires `journalctl` and permission to read the system journal.
+func getSleepPeriodsSince(since time.Time) ([]SleepPeriod, error) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return nil, fmt.Errorf("failed to get power log: journalctl not found")
+	}
+
+	out, err := exec.Command("journalctl",
+		"-u", "systemd-suspend.service",
+		"-u", "systemd-hibernate.service",
+		"-u", "systemd-hybrid-sleep.service",
+		"-u", "systemd-suspend-then-hibernate.service",
+		"-o", "short-iso",
+		"--no-pager",
+		"--since", since.Add(-time.Hour).Format("2006-01-02 15:04:05"),
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get power log: %w", err)
+	}
+	return parseJournaldLog(string(out)), nil
+}
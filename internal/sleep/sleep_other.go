@@ -0,0 +1,10 @@
+//go:build !darwin && !linux
+
+package sleep
+
+import "time"
+
+// getSleepPeriodsSince reports that sleep detection is unavailable on this platform.
+func getSleepPeriodsSince(since time.Time) ([]SleepPeriod, error) {
+	return nil, ErrUnsupported
+}